@@ -406,7 +406,7 @@ func (a *agileAPI) issueIsOnBoard(boardRefID string, issueKey string) (bool, err
 	var resp boardIssueRes
 
 	if resp, err := client.Get(&resp, append(a.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
-		if resp.StatusCode == http.StatusNotFound {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
 			// issue can't be on a board that doesnt exist 🤷‍♀️
 			sdk.LogDebug(a.logger, "recieved 404 when searching for issue board", "board", boardRefID, "issue", issueKey)
 			return false, nil
@@ -740,6 +740,43 @@ func (a *agileAPI) fetchBoardConfig(boardID int) ([]boardColumn, error) {
 	return columns, nil
 }
 
+// estimationBoardsChecked is the most scrum boards fetchEstimationFieldID checks
+const estimationBoardsChecked = 10
+
+// fetchEstimationFieldID returns the custom field the first scrum boards estimate with other than skip, or an empty
+// string if none of them do. company-managed projects estimate with a plain number field whose name is translated, so
+// this is the only way to find it
+func (a *agileAPI) fetchEstimationFieldID(skip string) (string, error) {
+	client := a.httpmanager.New(sdk.JoinURL(a.authConfig.APIURL, "/rest/agile/1.0/board"), nil)
+	qs := make(url.Values)
+	qs.Set("type", "scrum")
+	qs.Set("maxResults", strconv.Itoa(estimationBoardsChecked))
+	var boards struct {
+		Values []boardSource `json:"values"`
+	}
+	if _, err := client.Get(&boards, append(a.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
+		return "", fmt.Errorf("error fetching scrum boards: %w", err)
+	}
+	for _, board := range boards.Values {
+		theurl := sdk.JoinURL(a.authConfig.APIURL, fmt.Sprintf("/rest/agile/1.0/board/%d/configuration", board.ID))
+		var resp struct {
+			Estimation struct {
+				Field struct {
+					FieldID string `json:"fieldId"`
+				} `json:"field"`
+			} `json:"estimation"`
+		}
+		if _, err := a.httpmanager.New(theurl, nil).Get(&resp, a.authConfig.Middleware...); err != nil {
+			return "", fmt.Errorf("error fetching agile board %d config: %w", board.ID, err)
+		}
+		// boards can also estimate with the original time estimate or the issue count
+		if id := resp.Estimation.Field.FieldID; strings.HasPrefix(id, "customfield_") && id != skip {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
 // easyjson:skip
 type boardDetail struct {
 	ID         int
@@ -880,8 +917,19 @@ func (m *sprintManager) exportBoards(state *state) error {
 		var board = _board
 		boardsExported = append(boardsExported, strconv.Itoa(board.ID))
 
+		if state.checkpoint != nil && state.checkpoint.isBoardDone(board.ID) {
+			sdk.LogDebug(state.logger, "skipping board since it was already exported", "id", board.ID)
+			continue
+		}
+
 		m.async.Do(func() error {
-			return exportBoard(api, state.export.State(), state.pipe, customerID, state.integrationInstanceID, board, state.historical)
+			if err := exportBoard(api, state.export.State(), state.pipe, customerID, state.integrationInstanceID, board, state.historical); err != nil {
+				return err
+			}
+			if state.checkpoint != nil {
+				return state.checkpoint.markBoardDone(board.ID)
+			}
+			return nil
 		})
 	}
 	if len(boardsExported) > 0 {
@@ -0,0 +1,520 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/integration-sdk/agent"
+)
+
+// attachmentStore is implemented by agents which can store the content of attachments, so that users can open them
+// without having to log in to jira. the agent sdk doesn't have it yet, so we only mirror when our manager implements it
+type attachmentStore interface {
+	// StoreAttachment stores the content of an attachment and returns the id it's stored as
+	StoreAttachment(customerID string, integrationInstanceID string, refID string, contentType string, size int64, content io.Reader) (string, error)
+	// DeleteAttachment removes the stored content of an attachment
+	DeleteAttachment(customerID string, integrationInstanceID string, attachmentID string) error
+}
+
+// issueAttachmentsStateKeyPrefix is the prefix of the state key for the stored ids of the mirrored attachments of an
+// issue by their ref ids. it's removed along with the issue so the state only has attachments which still exist
+const issueAttachmentsStateKeyPrefix = "issue_attachments_"
+
+// attachmentDownloadTimeout is how long the download of an attachment can take, including reading its content, so a
+// download which stalls doesn't hold up the export
+const attachmentDownloadTimeout = 5 * time.Minute
+
+func (a attachment) ToModel() (*sdk.WorkIssueAttachments, error) {
+	var model sdk.WorkIssueAttachments
+	model.RefID = a.ID
+	model.Name = a.Filename
+	model.URL = a.Content
+	model.ThumbnailURL = a.Thumbnail
+	model.MimeType = a.MimeType
+	model.Size = int64(a.Size)
+	user := a.Author.AccountID // cloud
+	if user == "" {
+		user = a.Author.Key // hosted
+	}
+	model.UserRefID = user
+	created, err := parseTime(a.Created)
+	if err != nil {
+		return nil, err
+	}
+	sdk.ConvertTimeToDateModel(created, &model.CreatedDate)
+	return &model, nil
+}
+
+// contentTypeAllowed returns true if the content type matches one of the allowed types, which can end in /* to allow a
+// whole family of types such as image/*
+func contentTypeAllowed(allowed []string, contentType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	contentType = strings.ToLower(contentType)
+	for _, a := range allowed {
+		if a == "*" || a == "*/*" || a == contentType {
+			return true
+		}
+		if strings.HasSuffix(a, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(a, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachmentMirror downloads the content of attachments and gives it to the agent to store
+// easyjson:skip
+type attachmentMirror struct {
+	logger                sdk.Logger
+	store                 attachmentStore
+	state                 sdk.State
+	httpmanager           sdk.HTTPClientManager
+	authConfig            authConfig
+	customerID            string
+	integrationInstanceID string
+	policy                attachmentPolicy
+
+	mu        sync.Mutex
+	used      int64
+	exhausted bool
+}
+
+// newAttachmentMirror returns the mirror for an export or a webhook, or nil if mirroring isn't turned on. only exports
+// have a budget
+func (i *JiraIntegration) newAttachmentMirror(ci configIdentifier, state sdk.State, authConfig authConfig, export bool) *attachmentMirror {
+	policy := attachmentMirrorPolicy(ci.Config())
+	if policy == nil {
+		return nil
+	}
+	store, ok := i.manager.(attachmentStore)
+	if !ok {
+		sdk.LogWarn(ci.Logger(), "attachment mirroring is turned on but the agent can't store attachments")
+		return nil
+	}
+	if !export {
+		policy.budget = 0
+	}
+	return &attachmentMirror{
+		logger:                ci.Logger(),
+		store:                 store,
+		state:                 state,
+		httpmanager:           i.httpmanager,
+		authConfig:            authConfig,
+		customerID:            ci.CustomerID(),
+		integrationInstanceID: ci.IntegrationInstanceID(),
+		policy:                *policy,
+	}
+}
+
+// reserve takes size bytes from the budget, returning false if there isn't enough left
+func (m *attachmentMirror) reserve(size int64) bool {
+	if m.policy.budget <= 0 {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.used+size > m.policy.budget {
+		if !m.exhausted {
+			m.exhausted = true
+			sdk.LogInfo(m.logger, "attachment budget for the export is used up, the rest won't be mirrored", "budget", m.policy.budget, "used", m.used)
+		}
+		return false
+	}
+	m.used += size
+	return true
+}
+
+// release gives back size bytes which were reserved for an attachment that wasn't mirrored
+func (m *attachmentMirror) release(size int64) {
+	if m.policy.budget <= 0 {
+		return
+	}
+	m.mu.Lock()
+	m.used -= size
+	m.mu.Unlock()
+}
+
+// contentURL returns the url to download the attachment from. the content url from cloud is on the site which oauth2
+// can't use, but the api has the same content
+func (m *attachmentMirror) contentURL(attachment *sdk.WorkIssueAttachments) string {
+	if m.authConfig.Deployment == deploymentCloud {
+		return m.authConfig.restURL("/attachment/content", attachment.RefID)
+	}
+	return attachment.URL
+}
+
+// limitedReader fails the read once more than max bytes have been read, so that a store reading the content of an
+// attachment which is bigger than jira said fails instead of storing part of it
+// easyjson:skip
+type limitedReader struct {
+	r        io.Reader
+	max      int64
+	read     int64
+	exceeded bool
+}
+
+var errAttachmentTooBig = errors.New("attachment is bigger than the max size")
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.max {
+		l.exceeded = true
+		return n, errAttachmentTooBig
+	}
+	return n, err
+}
+
+// download returns the body of the attachment's content, which must be closed. it's sent with the middleware the same
+// way as the http client sends it, but the client reads the whole body into memory and this is streamed to the store
+func (m *attachmentMirror) download(attachment *sdk.WorkIssueAttachments) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, m.contentURL(attachment), nil)
+	if err != nil {
+		return nil, err
+	}
+	opts := &sdk.HTTPOptions{Request: req, Transport: http.DefaultTransport}
+	for _, o := range m.authConfig.Middleware {
+		if err := o(opts); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := (&http.Client{Transport: opts.Transport, Timeout: attachmentDownloadTimeout}).Do(opts.Request)
+	if err != nil {
+		return nil, err
+	}
+	// let the middleware see the response so the rate limit is kept. we don't retry, the next export will
+	opts.Response = &sdk.HTTPResponse{StatusCode: resp.StatusCode, Headers: resp.Header}
+	for _, o := range m.authConfig.Middleware {
+		if err := o(opts); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &sdk.HTTPError{StatusCode: resp.StatusCode, Body: strings.NewReader("")}
+	}
+	return resp.Body, nil
+}
+
+// mirror stores the content of the attachment and sets the id it's stored as. attachments which the policy doesn't
+// allow are left with only their url
+func (m *attachmentMirror) mirror(attachment *sdk.WorkIssueAttachments) error {
+	if attachment.Size > m.policy.maxSize {
+		sdk.LogDebug(m.logger, "not mirroring attachment which is too big", "attachment", attachment.RefID, "size", attachment.Size)
+		return nil
+	}
+	if !contentTypeAllowed(m.policy.contentTypes, attachment.MimeType) {
+		sdk.LogDebug(m.logger, "not mirroring attachment which isn't an allowed type", "attachment", attachment.RefID, "type", attachment.MimeType)
+		return nil
+	}
+	if !m.reserve(attachment.Size) {
+		return nil
+	}
+	body, err := m.download(attachment)
+	if err != nil {
+		m.release(attachment.Size)
+		return fmt.Errorf("error downloading attachment %s: %w", attachment.RefID, err)
+	}
+	defer body.Close()
+	// the size jira has might not be what we get
+	content := &limitedReader{r: io.LimitReader(body, m.policy.maxSize+1), max: m.policy.maxSize}
+	attachmentID, err := m.store.StoreAttachment(m.customerID, m.integrationInstanceID, attachment.RefID, attachment.MimeType, attachment.Size, content)
+	if content.exceeded {
+		sdk.LogDebug(m.logger, "not mirroring attachment which is too big", "attachment", attachment.RefID, "size", content.read)
+		m.release(attachment.Size)
+		return nil
+	}
+	if err != nil {
+		m.release(attachment.Size)
+		return fmt.Errorf("error storing attachment %s: %w", attachment.RefID, err)
+	}
+	attachment.AttachmentID = attachmentID
+	return nil
+}
+
+// storedAttachments returns the stored ids of the mirrored attachments of an issue by their ref ids
+func (m *attachmentMirror) storedAttachments(issueRefID string) (map[string]string, error) {
+	stored := make(map[string]string)
+	if _, err := m.state.Get(issueAttachmentsStateKeyPrefix+issueRefID, &stored); err != nil {
+		return nil, fmt.Errorf("error getting mirrored attachments from state: %w", err)
+	}
+	return stored, nil
+}
+
+// saveStoredAttachments saves the stored ids of the mirrored attachments of an issue, removing the key when it has none
+func (m *attachmentMirror) saveStoredAttachments(issueRefID string, stored map[string]string) error {
+	key := issueAttachmentsStateKeyPrefix + issueRefID
+	if len(stored) == 0 {
+		if !m.state.Exists(key) {
+			return nil
+		}
+		if err := m.state.Delete(key); err != nil {
+			return fmt.Errorf("error deleting mirrored attachments from state: %w", err)
+		}
+		return nil
+	}
+	if err := m.state.Set(key, stored); err != nil {
+		return fmt.Errorf("error saving mirrored attachments to state: %w", err)
+	}
+	return nil
+}
+
+// mirrorIssue mirrors the attachments of an issue, and deletes the stored content of the ones which are no longer on
+// it. none of them are mirrored when the issue is redacted. an attachment which can't be mirrored is left with only its
+// url rather than failing the export. it does nothing when mirroring is off
+func (m *attachmentMirror) mirrorIssue(issue *sdk.WorkIssue, redacted bool) {
+	if m == nil {
+		return
+	}
+	stored, err := m.storedAttachments(issue.RefID)
+	if err != nil {
+		sdk.LogWarn(m.logger, "error mirroring attachments", "issue", issue.RefID, "err", err)
+		return
+	}
+	current := make(map[string]string)
+	var changed bool
+	for n := range issue.Attachments {
+		if redacted {
+			// the content of any we mirrored before it was secured is deleted below
+			break
+		}
+		attachment := &issue.Attachments[n]
+		if attachmentID, ok := stored[attachment.RefID]; ok {
+			attachment.AttachmentID = attachmentID
+			current[attachment.RefID] = attachmentID
+			continue
+		}
+		if err := m.mirror(attachment); err != nil {
+			sdk.LogWarn(m.logger, "error mirroring attachment", "issue", issue.RefID, "err", err)
+			continue
+		}
+		if attachment.AttachmentID != "" {
+			current[attachment.RefID] = attachment.AttachmentID
+			changed = true
+		}
+	}
+	// these were deleted without us getting the webhook
+	for refID, attachmentID := range stored {
+		if _, ok := current[refID]; ok {
+			continue
+		}
+		if err := m.store.DeleteAttachment(m.customerID, m.integrationInstanceID, attachmentID); err != nil {
+			sdk.LogWarn(m.logger, "error deleting attachment", "issue", issue.RefID, "attachment", refID, "err", err)
+			current[refID] = attachmentID
+			continue
+		}
+		changed = true
+	}
+	if changed {
+		if err := m.saveStoredAttachments(issue.RefID, current); err != nil {
+			sdk.LogWarn(m.logger, "error mirroring attachments", "issue", issue.RefID, "err", err)
+		}
+	}
+}
+
+// mirrorAttachment mirrors an attachment which was added to an issue
+func (m *attachmentMirror) mirrorAttachment(issueRefID string, attachment *sdk.WorkIssueAttachments) error {
+	stored, err := m.storedAttachments(issueRefID)
+	if err != nil {
+		return err
+	}
+	if attachmentID, ok := stored[attachment.RefID]; ok {
+		attachment.AttachmentID = attachmentID
+		return nil
+	}
+	if err := m.mirror(attachment); err != nil {
+		return err
+	}
+	if attachment.AttachmentID == "" {
+		return nil
+	}
+	stored[attachment.RefID] = attachment.AttachmentID
+	return m.saveStoredAttachments(issueRefID, stored)
+}
+
+// remove deletes the stored content of an attachment which was deleted in jira
+func (m *attachmentMirror) remove(issueRefID string, refID string) error {
+	stored, err := m.storedAttachments(issueRefID)
+	if err != nil {
+		return err
+	}
+	attachmentID, ok := stored[refID]
+	if !ok {
+		return nil
+	}
+	if err := m.store.DeleteAttachment(m.customerID, m.integrationInstanceID, attachmentID); err != nil {
+		return fmt.Errorf("error deleting attachment %s: %w", refID, err)
+	}
+	delete(stored, refID)
+	return m.saveStoredAttachments(issueRefID, stored)
+}
+
+// removeIssue deletes the stored content of the attachments of an issue which was deleted in jira. it does nothing when
+// mirroring is off
+func (m *attachmentMirror) removeIssue(issueRefID string) error {
+	if m == nil {
+		return nil
+	}
+	stored, err := m.storedAttachments(issueRefID)
+	if err != nil {
+		return err
+	}
+	for refID, attachmentID := range stored {
+		if err := m.store.DeleteAttachment(m.customerID, m.integrationInstanceID, attachmentID); err != nil {
+			return fmt.Errorf("error deleting attachment %s: %w", refID, err)
+		}
+	}
+	return m.saveStoredAttachments(issueRefID, nil)
+}
+
+// attachmentFields is an attachment without its json methods, so that the webhook can decode the id differently
+// easyjson:skip
+type attachmentFields attachment
+
+// attachmentWebhook is the payload of the attachment webhooks, which send the ids as numbers and also has the issue
+// easyjson:skip
+type attachmentWebhook struct {
+	Attachment struct {
+		attachmentFields
+		ID      json.Number `json:"id"`
+		IssueID json.Number `json:"issueId"`
+	} `json:"attachment"`
+}
+
+func (w attachmentWebhook) ToModel() (*sdk.WorkIssueAttachments, error) {
+	a := attachment(w.Attachment.attachmentFields)
+	a.ID = w.Attachment.ID.String()
+	return a.ToModel()
+}
+
+// attachmentsColumn is the column of the attachments on the issue, which sdk.WorkIssueUpdate can't push or pull yet
+const attachmentsColumn = "attachments"
+
+// newIssueAttachmentsUpdate returns an update to the issue which pushes the attachment onto it
+func newIssueAttachmentsUpdate(customerID string, integrationInstanceID string, issueRefID string, attachment *sdk.WorkIssueAttachments) sdk.Model {
+	data := sdk.NewWorkIssueUpdate(customerID, integrationInstanceID, issueRefID, refType, sdk.WorkIssueUpdate{}).(*agent.UpdateData)
+	data.Push[attachmentsColumn] = sdk.Stringify([]sdk.WorkIssueAttachments{*attachment})
+	return data
+}
+
+// newIssueAttachmentsRemove returns an update to the issue which pulls the attachment off of it by its ref id, since the
+// webhook for a deleted attachment doesn't have all of what was pushed
+func newIssueAttachmentsRemove(customerID string, integrationInstanceID string, issueRefID string, attachmentRefID string) sdk.Model {
+	data := sdk.NewWorkIssueUpdate(customerID, integrationInstanceID, issueRefID, refType, sdk.WorkIssueUpdate{}).(*agent.UpdateData)
+	data.Pull[attachmentsColumn] = sdk.Stringify([]map[string]string{{"ref_id": attachmentRefID}})
+	return data
+}
+
+// fetchAttachmentIssueSecurity returns the security level of the attachment's issue, which is only fetched when the
+// restriction policy needs it since the webhook doesn't have it, and the policy
+func (i *JiraIntegration) fetchAttachmentIssueSecurity(webhook sdk.WebHook, authConfig authConfig, issueRefID string) (*securityLevel, restrictionPolicy, error) {
+	restriction := restrictedContent(webhook.Config())
+	if err := validateRestrictedContent(restriction); err != nil {
+		return nil, restriction, err
+	}
+	if restriction == restrictionPolicyExport {
+		return nil, restriction, nil
+	}
+	security, err := i.fetchIssueSecurityLevel(authConfig, issueRefID)
+	if err != nil {
+		return nil, restriction, err
+	}
+	return security, restriction, nil
+}
+
+func (i *JiraIntegration) webhookUpsertAttachment(logger sdk.Logger, webhook sdk.WebHook) error {
+	var event attachmentWebhook
+	if err := json.Unmarshal(webhook.Bytes(), &event); err != nil {
+		return fmt.Errorf("error parsing json for attachment: %w", err)
+	}
+	attachment, err := event.ToModel()
+	if err != nil {
+		return err
+	}
+	if event.Attachment.IssueID == "" {
+		// the issue gets the attachment the next time it's exported
+		sdk.LogDebug(logger, "attachment webhook has no issue", "attachment", attachment.RefID)
+		return nil
+	}
+	authConfig, err := i.createAuthConfig(webhook)
+	if err != nil {
+		return fmt.Errorf("error creating auth config: %w", err)
+	}
+	matches, err := i.issueMatchesFilter(logger, webhook, authConfig, issueFilter(webhook.Config()), event.Attachment.IssueID.String())
+	if err != nil {
+		return fmt.Errorf("error checking issue against the issue filter: %w", err)
+	}
+	if !matches {
+		sdk.LogDebug(logger, "skipping attachment on issue excluded by the issue filter", "attachment", attachment.RefID, "issue", event.Attachment.IssueID)
+		return nil
+	}
+	security, restriction, err := i.fetchAttachmentIssueSecurity(webhook, authConfig, event.Attachment.IssueID.String())
+	if err != nil {
+		return err
+	}
+	if security != nil && restriction == restrictionPolicySkip {
+		sdk.LogDebug(logger, "skipping attachment on restricted issue", "attachment", attachment.RefID, "issue", event.Attachment.IssueID)
+		return nil
+	}
+	if security != nil {
+		// it's redacted, so it only has its url
+		sdk.LogDebug(logger, "not mirroring attachment on redacted issue", "attachment", attachment.RefID, "issue", event.Attachment.IssueID)
+	} else if mirror := i.newAttachmentMirror(webhook, webhook.State(), authConfig, false); mirror != nil {
+		if err := mirror.mirrorAttachment(event.Attachment.IssueID.String(), attachment); err != nil {
+			return err
+		}
+	}
+	update := newIssueAttachmentsUpdate(webhook.CustomerID(), webhook.IntegrationInstanceID(), event.Attachment.IssueID.String(), attachment)
+	if err := webhook.Pipe().Write(update); err != nil {
+		return fmt.Errorf("error writing update to pipe: %w", err)
+	}
+	return nil
+}
+
+func (i *JiraIntegration) webhookDeleteAttachment(logger sdk.Logger, webhook sdk.WebHook) error {
+	var event attachmentWebhook
+	if err := json.Unmarshal(webhook.Bytes(), &event); err != nil {
+		return fmt.Errorf("error parsing json for attachment: %w", err)
+	}
+	attachment, err := event.ToModel()
+	if err != nil {
+		return err
+	}
+	authConfig, err := i.createAuthConfig(webhook)
+	if err != nil {
+		return fmt.Errorf("error creating auth config: %w", err)
+	}
+	if event.Attachment.IssueID == "" {
+		sdk.LogDebug(logger, "attachment webhook has no issue", "attachment", attachment.RefID)
+		return nil
+	}
+	security, restriction, err := i.fetchAttachmentIssueSecurity(webhook, authConfig, event.Attachment.IssueID.String())
+	if err != nil {
+		return err
+	}
+	if mirror := i.newAttachmentMirror(webhook, webhook.State(), authConfig, false); mirror != nil {
+		if err := mirror.remove(event.Attachment.IssueID.String(), attachment.RefID); err != nil {
+			return err
+		}
+	}
+	if security != nil && restriction == restrictionPolicySkip {
+		// the issue isn't exported so there's nothing to pull it from
+		sdk.LogDebug(logger, "skipping attachment on restricted issue", "attachment", attachment.RefID, "issue", event.Attachment.IssueID)
+		return nil
+	}
+	update := newIssueAttachmentsRemove(webhook.CustomerID(), webhook.IntegrationInstanceID(), event.Attachment.IssueID.String(), attachment.RefID)
+	if err := webhook.Pipe().Write(update); err != nil {
+		return fmt.Errorf("error writing update to pipe: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/agent/v4/sdk/sdktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockAttachmentManager is a manager which can store attachments
+type mockAttachmentManager struct {
+	mockManager
+	mu     sync.Mutex
+	stored map[string][]byte
+}
+
+var _ attachmentStore = (*mockAttachmentManager)(nil)
+
+func (m *mockAttachmentManager) StoreAttachment(customerID string, integrationInstanceID string, refID string, contentType string, size int64, content io.Reader) (string, error) {
+	buf, err := ioutil.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(buf)) != size {
+		return "", fmt.Errorf("expected %d bytes but got %d", size, len(buf))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := customerID + "/" + integrationInstanceID + "/" + refID
+	m.stored[id] = buf
+	return id, nil
+}
+
+func (m *mockAttachmentManager) DeleteAttachment(customerID string, integrationInstanceID string, attachmentID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.stored, attachmentID)
+	return nil
+}
+
+func newMockAttachmentIntegration() (*JiraIntegration, *mockAttachmentManager) {
+	httpmanager := &mockHTTPManager{}
+	manager := &mockAttachmentManager{mockManager: mockManager{httpmanager: httpmanager}, stored: make(map[string][]byte)}
+	return &JiraIntegration{manager: manager, httpmanager: httpmanager}, manager
+}
+
+func exportedAttachments(pipe *mockPipe) map[string]sdk.WorkIssueAttachments {
+	attachments := make(map[string]sdk.WorkIssueAttachments)
+	for _, object := range pipe.written {
+		if issue, ok := object.(*sdk.WorkIssue); ok {
+			for _, a := range issue.Attachments {
+				attachments[a.RefID] = a
+			}
+		}
+	}
+	return attachments
+}
+
+func TestContentTypeAllowed(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(contentTypeAllowed(defaultAttachmentContentTypes, "image/png"))
+	assert.True(contentTypeAllowed(defaultAttachmentContentTypes, "text/plain; charset=UTF-8"))
+	assert.True(contentTypeAllowed(defaultAttachmentContentTypes, "Application/PDF"))
+	assert.False(contentTypeAllowed(defaultAttachmentContentTypes, "application/zip"))
+	assert.False(contentTypeAllowed(defaultAttachmentContentTypes, "text/html"))
+	assert.False(contentTypeAllowed(defaultAttachmentContentTypes, ""))
+	assert.True(contentTypeAllowed([]string{"*"}, "application/zip"))
+}
+
+func TestAttachmentMirrorPolicy(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(attachmentMirrorPolicy(sdk.NewConfig(nil)))
+	assert.Nil(attachmentMirrorPolicy(sdk.NewConfig(map[string]interface{}{configKeyMirrorAttachments: false})))
+	policy := attachmentMirrorPolicy(sdk.NewConfig(map[string]interface{}{configKeyMirrorAttachments: true}))
+	assert.Equal(&attachmentPolicy{maxSize: defaultAttachmentMaxSize, contentTypes: defaultAttachmentContentTypes, budget: defaultAttachmentExportBudget}, policy)
+	policy = attachmentMirrorPolicy(sdk.NewConfig(map[string]interface{}{
+		configKeyMirrorAttachments:      "true",
+		configKeyAttachmentMaxSize:      "1024",
+		configKeyAttachmentContentTypes: " image/* , Application/ZIP,,image/*",
+		configKeyAttachmentExportBudget: "0",
+	}))
+	assert.Equal(&attachmentPolicy{maxSize: 1024, contentTypes: []string{"image/*", "application/zip"}, budget: 0}, policy)
+}
+
+func TestExportMirrorsAttachments(t *testing.T) {
+	for _, deployment := range testDeployments {
+		t.Run(deployment, func(t *testing.T) {
+			assert := assert.New(t)
+			jira := newFakeJiraDeployment(deployment)
+			defer jira.Close()
+			jira.addProject("10000", "ABC", 1)
+			issue := &jira.issues["10000"][0]
+			jira.addAttachment(issue, "1", "image/png", []byte("png"))
+			jira.addAttachment(issue, "2", "application/zip", []byte("zip"))
+			jira.addAttachment(issue, "3", "image/jpeg", []byte("too big to mirror"))
+
+			integration, manager := newMockAttachmentIntegration()
+			state := newMockState()
+			export := newMockExport(jira.URL(), state, true)
+			export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentMaxSize: "10"})
+			assert.NoError(integration.Export(export))
+			attachments := exportedAttachments(export.pipe)
+			assert.Len(attachments, 3)
+			assert.Equal("1234/1/1", attachments["1"].AttachmentID)
+			assert.Empty(attachments["2"].AttachmentID)
+			assert.Empty(attachments["3"].AttachmentID)
+			assert.Equal(map[string][]byte{"1234/1/1": []byte("png")}, manager.stored)
+			assert.Equal([]string{"1"}, jira.downloads)
+
+			// mirrored attachments aren't downloaded again
+			export = newMockExport(jira.URL(), state, true)
+			export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentMaxSize: "10"})
+			assert.NoError(integration.Export(export))
+			assert.Equal("1234/1/1", exportedAttachments(export.pipe)["1"].AttachmentID)
+			assert.Equal([]string{"1"}, jira.downloads)
+
+			// the stored content of an attachment which was removed from the issue is deleted
+			issue.Fields["attachment"] = issue.Fields["attachment"].([]attachment)[1:]
+			export = newMockExport(jira.URL(), state, true)
+			export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentMaxSize: "10"})
+			assert.NoError(integration.Export(export))
+			assert.Empty(manager.stored)
+			assert.False(state.Exists(issueAttachmentsStateKeyPrefix + issue.ID))
+		})
+	}
+}
+
+func TestExportAttachmentBudget(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	issue := &jira.issues["10000"][0]
+	jira.addAttachment(issue, "1", "text/plain", []byte("1234"))
+	jira.addAttachment(issue, "2", "text/plain", []byte("5678"))
+
+	integration, manager := newMockAttachmentIntegration()
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentExportBudget: "6"})
+	assert.NoError(integration.Export(export))
+	assert.Len(manager.stored, 1)
+	assert.Len(jira.downloads, 1)
+}
+
+func TestExportAttachmentBudgetReleasedWhenNotMirrored(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	issue := &jira.issues["10000"][0]
+	jira.addAttachment(issue, "1", "text/plain", []byte("much more than jira says"))
+	issue.Fields["attachment"].([]attachment)[0].Size = 4
+	jira.addAttachment(issue, "2", "text/plain", []byte("5678"))
+
+	// the first one turns out to be too big so what it reserved can be used by the second
+	integration, manager := newMockAttachmentIntegration()
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentMaxSize: "10", configKeyAttachmentExportBudget: "6"})
+	assert.NoError(integration.Export(export))
+	attachments := exportedAttachments(export.pipe)
+	assert.Empty(attachments["1"].AttachmentID)
+	assert.Equal("1234/1/2", attachments["2"].AttachmentID)
+	assert.Equal(map[string][]byte{"1234/1/2": []byte("5678")}, manager.stored)
+}
+
+func TestExportAttachmentBiggerThanJiraSays(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	issue := &jira.issues["10000"][0]
+	jira.addAttachment(issue, "1", "text/plain", []byte("much more than jira says"))
+	issue.Fields["attachment"].([]attachment)[0].Size = 4
+
+	integration, manager := newMockAttachmentIntegration()
+	state := newMockState()
+	export := newMockExport(jira.URL(), state, true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyAttachmentMaxSize: "10"})
+	assert.NoError(integration.Export(export))
+	assert.Empty(exportedAttachments(export.pipe)["1"].AttachmentID)
+	assert.Empty(manager.stored)
+	assert.False(state.Exists(issueAttachmentsStateKeyPrefix + issue.ID))
+}
+
+func TestExportAttachmentsWithoutStore(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	jira.addAttachment(&jira.issues["10000"][0], "1", "image/png", []byte("png"))
+
+	// the manager can't store attachments so they only have their url
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true})
+	assert.NoError(newMockIntegration().Export(export))
+	attachments := exportedAttachments(export.pipe)
+	assert.Empty(attachments["1"].AttachmentID)
+	assert.Equal(jira.URL()+"/secure/attachment/1/file1", attachments["1"].URL)
+	assert.Empty(jira.downloads)
+}
+
+func TestWebhookAttachmentCreatedAndDeleted(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJiraDeployment(deploymentDataCenter)
+	defer jira.Close()
+	jira.attachments["10001"] = []byte("%PDF")
+
+	integration, manager := newMockAttachmentIntegration()
+	state := newMockState()
+	newWebhook := func(event string) *mockWebHook {
+		webhook := &mockWebHook{
+			raw:   []byte(fmt.Sprintf(`{"webhookEvent":%q,"attachment":{"id":"10001","issueId":"10000","filename":"spec.pdf","author":{"key":"robin"},"created":"2020-10-01T10:00:00.000+0000","size":4,"mimeType":"application/pdf","content":"%s/secure/attachment/10001/spec.pdf"}}`, event, jira.URL())),
+			data:  make(map[string]interface{}),
+			pipe:  &sdktest.MockPipe{},
+			state: state,
+		}
+		assert.NoError(webhook.config.Parse(makeMockAuth(jira.URL())))
+		webhook.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true})
+		return webhook
+	}
+	assert.NoError(integration.WebHook(newWebhook("attachment_created")))
+	assert.Equal(map[string][]byte{"1234/1/10001": []byte("%PDF")}, manager.stored)
+	assert.True(state.Exists(issueAttachmentsStateKeyPrefix + "10000"))
+
+	assert.NoError(integration.WebHook(newWebhook("attachment_deleted")))
+	assert.Empty(manager.stored)
+	assert.False(state.Exists(issueAttachmentsStateKeyPrefix + "10000"))
+}
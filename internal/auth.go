@@ -11,12 +11,26 @@ import (
 
 // easyjson:skip
 type authConfig struct {
-	WebsiteURL       string
-	APIURL           string
+	WebsiteURL string
+	APIURL     string
+	// APIPath is the base path of the rest api, it's v3 on cloud but server and data center only have v2
+	APIPath string
+	// Deployment is the deployment type from the server info, Cloud, Server or DataCenter
+	Deployment       string
 	Middleware       []sdk.WithHTTPOption
 	SupportsAgileAPI bool
 }
 
+// restURL returns the url for a path of the rest api, which is relative to the api version such as /issue/ABC-1
+func (a authConfig) restURL(paths ...string) string {
+	return sdk.JoinURL(append([]string{a.APIURL, a.APIPath}, paths...)...)
+}
+
+// usesADF returns true if descriptions and comments are sent as ADF, only v3 of the api uses it and v2 uses wiki markup
+func (a authConfig) usesADF() bool {
+	return a.APIPath == apiPathV3
+}
+
 type auth interface {
 	Apply() (authConfig, error)
 }
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const changelogPageSize = 100 // 100 is the max
+
+// hasMoreChangelogs returns true if jira didn't return all of the changelog histories with the issue
+func (i issueSource) hasMoreChangelogs() bool {
+	return i.Changelog.Total > len(i.Changelog.Histories)
+}
+
+// fetchIssueChangelogs will fetch all of the changelog histories for the issue if it has more than were returned
+// with the issue, since the changelog expand only includes the most recent histories
+func (i *JiraIntegration) fetchIssueChangelogs(logger sdk.Logger, control sdk.Control, authConfig authConfig, issue *issueSource) error {
+	if !issue.hasMoreChangelogs() {
+		return nil
+	}
+	theurl := authConfig.restURL("/issue", issue.ID, "/changelog")
+	client := i.httpmanager.New(theurl, nil)
+	queryParams := make(url.Values)
+	queryParams.Set("maxResults", strconv.Itoa(changelogPageSize))
+	ts := time.Now()
+	histories := make([]changeLogHistory, 0, issue.Changelog.Total)
+	for {
+		queryParams.Set("startAt", strconv.Itoa(len(histories)))
+		var resp changeLogQueryResult
+		r, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
+		if err != nil {
+			if r != nil && r.StatusCode == http.StatusNotFound {
+				// the issue was deleted or moved since the search, keep the histories we already have
+				sdk.LogWarn(logger, "changelog endpoint returned 404 for issue", "error_body", string(r.Body), "issue", issue.Key)
+				return nil
+			}
+			return fmt.Errorf("error fetching changelogs for issue %s: %w", issue.Key, err)
+		}
+		histories = append(histories, resp.Values...)
+		if resp.IsLast || len(resp.Values) == 0 || len(histories) >= resp.Total {
+			break
+		}
+	}
+	sdk.LogDebug(logger, "fetched issue changelogs", "issue", issue.Key, "len", len(histories), "embedded", len(issue.Changelog.Histories), "duration", time.Since(ts))
+	issue.Changelog.Histories = mergeChangeLogHistories(issue.Changelog.Histories, histories)
+	issue.Changelog.StartAt = 0
+	issue.Changelog.MaxResults = len(issue.Changelog.Histories)
+	issue.Changelog.Total = len(issue.Changelog.Histories)
+	return nil
+}
+
+// mergeChangeLogHistories combines the histories, removing any duplicates, and returns them from the newest to
+// the oldest which is the same order as the histories returned with an issue
+func mergeChangeLogHistories(histories ...[]changeLogHistory) []changeLogHistory {
+	found := make(map[string]bool)
+	merged := make([]changeLogHistory, 0)
+	for _, list := range histories {
+		for _, history := range list {
+			if found[history.ID] {
+				continue
+			}
+			found[history.ID] = true
+			merged = append(merged, history)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, _ := parseTime(merged[i].Created)
+		b, _ := parseTime(merged[j].Created)
+		if a.Equal(b) {
+			// history ids are sequential so use them when the times are the same
+			x, _ := strconv.ParseInt(merged[i].ID, 10, 64)
+			y, _ := strconv.ParseInt(merged[j].ID, 10, 64)
+			return x > y
+		}
+		return a.After(b)
+	})
+	return merged
+}
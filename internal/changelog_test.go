@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadChangelogFixture(t *testing.T) []changeLogHistory {
+	var result changeLogQueryResult
+	if err := json.Unmarshal(loadFile("testdata/issue_changelog.json"), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result.Values
+}
+
+func TestMergeChangeLogHistories(t *testing.T) {
+	assert := assert.New(t)
+	histories := loadChangelogFixture(t)
+	// the issue has the newest ones in reverse order and the api returns them all oldest first
+	embedded := make([]changeLogHistory, 0)
+	for h := len(histories) - 1; h >= len(histories)-100; h-- {
+		embedded = append(embedded, histories[h])
+	}
+	merged := mergeChangeLogHistories(embedded, histories)
+	assert.Len(merged, 350)
+	assert.Equal("20349", merged[0].ID)
+	assert.Equal("20000", merged[349].ID)
+}
+
+func TestExportFetchesAllChangelogs(t *testing.T) {
+	for _, deployment := range testDeployments {
+		t.Run(deployment, func(t *testing.T) {
+			assert := assert.New(t)
+			jira := newFakeJiraDeployment(deployment)
+			defer jira.Close()
+			jira.addProject("10000", "ABC", 2)
+			histories := loadChangelogFixture(t)
+			issue := &jira.issues["10000"][0]
+			issue.Fields["description"] = jira.content("Fix the login page")
+			jira.changelogs[issue.ID] = histories
+			// the search only returns the newest 100 histories, from newest to oldest
+			for h := len(histories) - 1; h >= len(histories)-100; h-- {
+				issue.Changelog.Histories = append(issue.Changelog.Histories, histories[h])
+			}
+			issue.Changelog.MaxResults = 100
+			issue.Changelog.Total = len(histories)
+
+			export := newMockExport(jira.URL(), newMockState(), true)
+			assert.NoError(newMockIntegration().Export(export))
+
+			var found bool
+			for _, object := range export.pipe.written {
+				if i, ok := object.(*sdk.WorkIssue); ok && i.RefID == issue.ID {
+					found = true
+					assert.Contains(i.Description, "Fix the login page")
+					assert.Len(i.ChangeLog, 350)
+					for n, changelog := range i.ChangeLog {
+						assert.Equal(histories[n].ID, changelog.RefID)
+						if n > 0 {
+							assert.True(changelog.Ordinal > i.ChangeLog[n-1].Ordinal)
+						}
+					}
+					assert.Equal("To Do", i.ChangeLog[0].From)
+					assert.Equal("In Progress", i.ChangeLog[0].To)
+				}
+			}
+			assert.True(found)
+		})
+	}
+}
+
+func TestExportChangelogsNotFound(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 2)
+	histories := loadChangelogFixture(t)
+	// the issue was deleted after the search so the changelog api returns a 404
+	issue := &jira.issues["10000"][0]
+	for h := len(histories) - 1; h >= len(histories)-100; h-- {
+		issue.Changelog.Histories = append(issue.Changelog.Histories, histories[h])
+	}
+	issue.Changelog.MaxResults = 100
+	issue.Changelog.Total = len(histories)
+
+	export := newMockExport(jira.URL(), newMockState(), true)
+	assert.NoError(newMockIntegration().Export(export))
+
+	var found bool
+	for _, object := range export.pipe.written {
+		if i, ok := object.(*sdk.WorkIssue); ok && i.RefID == issue.ID {
+			found = true
+			assert.Len(i.ChangeLog, 100)
+		}
+	}
+	assert.True(found)
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const exportCheckpointStateKey = "export_checkpoint"
+
+// exportCheckpoint tracks the progress of a historical export so that an interrupted export can pick up where it left off
+// easyjson:skip
+type exportCheckpoint struct {
+	// Started is when the interrupted export was originally started
+	Started time.Time `json:"started"`
+	// Projects are the projects which have had all their issues exported
+	Projects map[string]bool `json:"projects"`
+	// Cursors is the key of the last issue exported for each project which is in progress
+	Cursors map[string]string `json:"cursors"`
+	// Boards are the agile boards which have been exported
+	Boards map[int]bool `json:"boards"`
+
+	state sdk.State
+	mu    sync.Mutex
+}
+
+func newExportCheckpoint(state sdk.State, started time.Time) *exportCheckpoint {
+	return &exportCheckpoint{
+		Started:  started,
+		Projects: make(map[string]bool),
+		Cursors:  make(map[string]string),
+		Boards:   make(map[int]bool),
+		state:    state,
+	}
+}
+
+// loadExportCheckpoint will return the checkpoint of a previously interrupted export or nil if there isn't one
+func loadExportCheckpoint(state sdk.State) (*exportCheckpoint, error) {
+	checkpoint := newExportCheckpoint(state, time.Time{})
+	found, err := state.Get(exportCheckpointStateKey, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching export checkpoint from state: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	// older checkpoints could be missing some of these so make sure they are set
+	if checkpoint.Projects == nil {
+		checkpoint.Projects = make(map[string]bool)
+	}
+	if checkpoint.Cursors == nil {
+		checkpoint.Cursors = make(map[string]string)
+	}
+	if checkpoint.Boards == nil {
+		checkpoint.Boards = make(map[int]bool)
+	}
+	return checkpoint, nil
+}
+
+// save must be called with the lock held. the state is flushed each time so the checkpoint survives the export being killed
+func (c *exportCheckpoint) save() error {
+	if err := c.state.Set(exportCheckpointStateKey, c); err != nil {
+		return fmt.Errorf("error saving export checkpoint to state: %w", err)
+	}
+	if err := c.state.Flush(); err != nil {
+		return fmt.Errorf("error flushing export checkpoint to state: %w", err)
+	}
+	return nil
+}
+
+// start will save the checkpoint so that the export can be resumed from the beginning
+func (c *exportCheckpoint) start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+func (c *exportCheckpoint) isProjectDone(projectID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Projects[projectID]
+}
+
+func (c *exportCheckpoint) markProjectDone(projectID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Projects[projectID] = true
+	delete(c.Cursors, projectID)
+	return c.save()
+}
+
+// issueCursor returns the key of the last issue exported for the project or empty if none have been
+func (c *exportCheckpoint) issueCursor(projectID string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Cursors[projectID]
+}
+
+func (c *exportCheckpoint) setIssueCursor(projectID string, issueKey string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cursors[projectID] = issueKey
+	return c.save()
+}
+
+func (c *exportCheckpoint) isBoardDone(boardID int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Boards[boardID]
+}
+
+func (c *exportCheckpoint) markBoardDone(boardID int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Boards[boardID] = true
+	return c.save()
+}
+
+// clear will remove the checkpoint once the export has completed successfully
+func (c *exportCheckpoint) clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.state.Delete(exportCheckpointStateKey); err != nil {
+		return fmt.Errorf("error removing export checkpoint from state: %w", err)
+	}
+	return nil
+}
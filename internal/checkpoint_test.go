@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueSearchResumableJQL(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("project in (10000) ORDER BY key ASC", issueSearchResumableJQL([]string{"10000"}, "", ""))
+	assert.Equal(`project in (10000) AND key > "ABC-50" ORDER BY key ASC`, issueSearchResumableJQL([]string{"10000"}, "ABC-50", ""))
+	assert.Equal(`project in (10000) AND key > "ABC-50" AND (labels != hr-confidential) ORDER BY key ASC`, issueSearchResumableJQL([]string{"10000"}, "ABC-50", "labels != hr-confidential"))
+}
+
+func TestExportResumesAfterInterruption(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.pageSize = 10
+	jira.addProject("10000", "ABC", 250)
+	jira.addProject("10001", "DEF", 35)
+	jira.addBoard(1, 10000, "ABC")
+	jira.addBoard(2, 10000, "ABC")
+	jira.addBoard(3, 10001, "DEF")
+	// fail part way through the second batch of pages for the first project
+	jira.failSearch = func(jql string, startAt int) bool {
+		return strings.Contains(jql, "10000") && startAt == 70
+	}
+	jira.failBoards[2] = true
+
+	integration := newMockIntegration()
+	state := newMockState()
+
+	first := newMockExport(jira.URL(), state, true)
+	assert.Error(integration.Export(first))
+	assert.True(state.Exists(exportCheckpointStateKey))
+	assert.True(state.isFlushed(exportCheckpointStateKey))
+	checkpoint, err := loadExportCheckpoint(state)
+	assert.NoError(err)
+	assert.Equal("ABC-50", checkpoint.Cursors["10000"])
+	assert.False(checkpoint.Projects["10000"])
+	assert.True(checkpoint.Boards[1])
+	assert.False(checkpoint.Boards[2])
+	assert.False(state.Exists(projectWatermarksStateKey))
+
+	jira.mu.Lock()
+	jira.failSearch = nil
+	jira.failBoards[2] = false
+	jira.mu.Unlock()
+
+	// the second export isn't historical but should still finish the interrupted historical export
+	second := newMockExport(jira.URL(), state, false)
+	assert.NoError(integration.Export(second))
+	assert.False(state.Exists(exportCheckpointStateKey))
+	watermarks, err := loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.True(checkpoint.Started.Equal(watermarks.get("10000")))
+	assert.True(checkpoint.Started.Equal(watermarks.get("10001")))
+
+	issues := make(map[string]int)
+	boards := make(map[string]int)
+	for _, pipe := range []*mockPipe{first.pipe, second.pipe} {
+		for _, object := range pipe.written {
+			switch v := object.(type) {
+			case *sdk.WorkIssue:
+				issues[v.RefID]++
+			case *sdk.AgileBoard:
+				boards[v.RefID]++
+			}
+		}
+	}
+	assert.Len(issues, 285)
+	for refID, count := range issues {
+		assert.Equal(1, count, "issue %s was exported more than once", refID)
+	}
+	assert.Equal(map[string]int{"1": 1, "2": 1, "3": 1}, boards)
+}
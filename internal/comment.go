@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/pinpt/adf"
 	"github.com/pinpt/agent/v4/sdk"
 )
 
@@ -17,17 +21,30 @@ type comment struct {
 	Body    json.RawMessage `json:"body"`
 	Created string          `json:"created"`
 	Updated string          `json:"updated"`
+	// Visibility is set when only a role or group can see the comment
+	Visibility *commentVisibility `json:"visibility"`
+}
+
+// restricted returns true if only some users can see the comment, because of its visibility or the security level of
+// its issue
+func (c comment) restricted(issueSecurity *securityLevel) bool {
+	return issueSecurity != nil || (c.Visibility != nil && c.Visibility.Value != "")
+}
 
-	/**
-		there is a visibility flag so we probably at some point want to consider
-		bringing that into the model
-		"visibility": {
-	        "type": "role",
-	        "value": "Administrators"
-	      }*/
+// commentQueryResult is a page of results from the issue comment api, which is also how comments are included with an issue
+type commentQueryResult struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Comments   []comment `json:"comments"`
 }
 
-func (c comment) ToModel(customerID string, integrationInstanceID string, websiteURL string, userManager UserManager, projectID string, issueID string, issueKey string) (*sdk.WorkIssueComment, error) {
+// ToModel returns the comment with the restriction policy applied, which is nil if it should be skipped
+func (c comment) ToModel(customerID string, integrationInstanceID string, websiteURL string, userManager UserManager, projectID string, issueID string, issueKey string, restriction restrictionPolicy, issueSecurity *securityLevel) (*sdk.WorkIssueComment, error) {
+	restricted := c.restricted(issueSecurity)
+	if restricted && restriction == restrictionPolicySkip {
+		return nil, nil
+	}
 	if err := userManager.Emit(c.Author); err != nil {
 		return nil, err
 	}
@@ -52,8 +69,8 @@ func (c comment) ToModel(customerID string, integrationInstanceID string, websit
 	comment.UserRefID = c.Author.RefID()
 	comment.URL = issueCommentURL(websiteURL, issueKey, c.ID)
 
-	if c.Body != nil {
-		html, err := adf.GenerateHTMLFromADF(c.Body)
+	if c.Body != nil && !(restricted && restriction == restrictionPolicyRedact) {
+		html, err := contentToHTML(c.Body)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing comment body: %w", err)
 		}
@@ -62,8 +79,9 @@ func (c comment) ToModel(customerID string, integrationInstanceID string, websit
 	return comment, nil
 }
 
-func (i *JiraIntegration) fetchComment(authCfg authConfig, userManager UserManager, integrationInstanceID, customerID, issueRefID, issueKey, commentRefID, projectID string) (*sdk.WorkIssueComment, error) {
-	theurl := sdk.JoinURL(authCfg.APIURL, fmt.Sprintf("/rest/api/3/issue/%s/comment/%s", issueRefID, commentRefID))
+// fetchComment returns the comment with the restriction policy applied, or nil if it's not found or should be skipped
+func (i *JiraIntegration) fetchComment(authCfg authConfig, userManager UserManager, integrationInstanceID, customerID, issueRefID, issueKey, commentRefID, projectID string, restriction restrictionPolicy, issueSecurity *securityLevel) (*sdk.WorkIssueComment, error) {
+	theurl := authCfg.restURL(fmt.Sprintf("/issue/%s/comment/%s", issueRefID, commentRefID))
 	client := i.httpmanager.New(theurl, nil)
 	issueID := sdk.NewWorkIssueID(customerID, issueRefID, refType)
 	qs := url.Values{}
@@ -75,5 +93,261 @@ func (i *JiraIntegration) fetchComment(authCfg authConfig, userManager UserManag
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, nil
 	}
-	return c.ToModel(customerID, integrationInstanceID, authCfg.WebsiteURL, userManager, projectID, issueID, issueKey)
+	return c.ToModel(customerID, integrationInstanceID, authCfg.WebsiteURL, userManager, projectID, issueID, issueKey, restriction, issueSecurity)
+}
+
+const commentsPageSize = 100 // 100 is the max, 50 is the default
+
+// fetchIssueComments will fetch all of the comments for the issue if it has more than were returned with it
+func (i *JiraIntegration) fetchIssueComments(logger sdk.Logger, control sdk.Control, authConfig authConfig, issue *issueSource) error {
+	val, ok := issue.Fields["comment"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var embedded commentQueryResult
+	if err := sdk.MapToStruct(val, &embedded); err != nil {
+		return fmt.Errorf("error decoding comments for issue %s: %w", issue.Key, err)
+	}
+	if embedded.Total <= len(embedded.Comments) {
+		return nil
+	}
+	theurl := authConfig.restURL("/issue", issue.ID, "/comment")
+	client := i.httpmanager.New(theurl, nil)
+	queryParams := make(url.Values)
+	queryParams.Set("maxResults", strconv.Itoa(commentsPageSize))
+	queryParams.Set("orderBy", "created")
+	ts := time.Now()
+	comments := make([]comment, 0, embedded.Total)
+	for {
+		queryParams.Set("startAt", strconv.Itoa(len(comments)))
+		var resp commentQueryResult
+		r, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
+		if err != nil {
+			if r != nil && r.StatusCode == http.StatusNotFound {
+				// the issue was deleted or moved since the search, keep the comments we already have
+				sdk.LogWarn(logger, "comments endpoint returned 404 for issue", "error_body", string(r.Body), "issue", issue.Key)
+				return nil
+			}
+			return fmt.Errorf("error fetching comments for issue %s: %w", issue.Key, err)
+		}
+		comments = append(comments, resp.Comments...)
+		if len(resp.Comments) == 0 || len(comments) >= resp.Total {
+			break
+		}
+	}
+	sdk.LogDebug(logger, "fetched issue comments", "issue", issue.Key, "len", len(comments), "embedded", len(embedded.Comments), "duration", time.Since(ts))
+	// replace the embedded comments so they are all converted with the issue
+	issue.Fields["comment"] = commentQueryResult{
+		MaxResults: len(comments),
+		Total:      len(comments),
+		Comments:   comments,
+	}
+	return nil
+}
+
+const (
+	// issueCommentsStateKeyPrefix is how we used to keep the comments of each issue, they are moved to the project as
+	// each issue is exported again
+	issueCommentsStateKeyPrefix   = "issue_comments_"
+	projectCommentsStateKeyPrefix = "project_comments_"
+)
+
+// exportedComments tracks the ids of the comments we exported for each issue so we can deactivate the ones which are
+// removed. they are kept in one state key per project, which is loaded the first time one of its issues is exported
+// and only written by save
+// easyjson:skip
+type exportedComments struct {
+	state    sdk.State
+	mu       sync.Mutex
+	projects map[string]map[int64][]int64 // comment ids by issue id by project id
+	dirty    map[string]bool
+}
+
+func newExportedComments(state sdk.State) *exportedComments {
+	return &exportedComments{
+		state:    state,
+		projects: make(map[string]map[int64][]int64),
+		dirty:    make(map[string]bool),
+	}
+}
+
+// encodeProjectComments returns the comments of each issue as base 36 deltas, like encodeIssueIDs
+func encodeProjectComments(comments map[int64][]int64) string {
+	issueIDs := make([]int64, 0, len(comments))
+	for id := range comments {
+		issueIDs = append(issueIDs, id)
+	}
+	sort.Slice(issueIDs, func(i, j int) bool { return issueIDs[i] < issueIDs[j] })
+	var sb strings.Builder
+	var last int64
+	for n, id := range issueIDs {
+		if n > 0 {
+			sb.WriteByte(';')
+		}
+		sb.WriteString(strconv.FormatInt(id-last, 36))
+		sb.WriteByte(':')
+		sb.WriteString(encodeIssueIDs(comments[id]))
+		last = id
+	}
+	return sb.String()
+}
+
+func decodeProjectComments(val string) (map[int64][]int64, error) {
+	comments := make(map[int64][]int64)
+	if val == "" {
+		return comments, nil
+	}
+	var last int64
+	for _, entry := range strings.Split(val, ";") {
+		tok := strings.SplitN(entry, ":", 2)
+		if len(tok) != 2 {
+			return nil, fmt.Errorf("error decoding project comments: invalid entry %s", entry)
+		}
+		d, err := strconv.ParseInt(tok[0], 36, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding project comments: %w", err)
+		}
+		last += d
+		ids, err := decodeIssueIDs(tok[1])
+		if err != nil {
+			return nil, err
+		}
+		comments[last] = ids
+	}
+	return comments, nil
+}
+
+// load must be called with the lock held
+func (c *exportedComments) load(projectID string) (map[int64][]int64, error) {
+	if comments, ok := c.projects[projectID]; ok {
+		return comments, nil
+	}
+	var val string
+	if _, err := c.state.Get(projectCommentsStateKeyPrefix+projectID, &val); err != nil {
+		return nil, fmt.Errorf("error fetching project comments from state: %w", err)
+	}
+	comments, err := decodeProjectComments(val)
+	if err != nil {
+		return nil, err
+	}
+	c.projects[projectID] = comments
+	return comments, nil
+}
+
+// previous must be called with the lock held, it returns the ids of the comments we last exported for the issue
+func (c *exportedComments) previous(comments map[int64][]int64, issueID int64, issueRefID string) ([]int64, error) {
+	if ids, ok := comments[issueID]; ok {
+		return ids, nil
+	}
+	key := issueCommentsStateKeyPrefix + issueRefID
+	legacy := make([]string, 0)
+	found, err := c.state.Get(key, &legacy)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching previous comments from state: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(legacy))
+	for _, refID := range legacy {
+		id, err := strconv.ParseInt(refID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing comment id %s: %w", refID, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := c.state.Delete(key); err != nil {
+		return nil, fmt.Errorf("error removing previous comments from state: %w", err)
+	}
+	return ids, nil
+}
+
+// deactivateRemoved will mark any comments we exported for the issue before which no longer exist as inactive
+func (c *exportedComments) deactivateRemoved(logger sdk.Logger, pipe sdk.Pipe, customerID string, integrationInstanceID string, projectID string, issueRefID string, comments []*sdk.WorkIssueComment) error {
+	issueID, err := strconv.ParseInt(issueRefID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing issue id %s: %w", issueRefID, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	project, err := c.load(projectID)
+	if err != nil {
+		return err
+	}
+	previous, err := c.previous(project, issueID, issueRefID)
+	if err != nil {
+		return err
+	}
+	current := make([]int64, 0, len(comments))
+	found := make(map[int64]bool)
+	for _, comment := range comments {
+		id, err := strconv.ParseInt(comment.RefID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing comment id %s: %w", comment.RefID, err)
+		}
+		current = append(current, id)
+		found[id] = true
+	}
+	for _, id := range previous {
+		if found[id] {
+			continue
+		}
+		refID := strconv.FormatInt(id, 10)
+		sdk.LogDebug(logger, "deactivating comment which was removed from issue", "comment", refID, "issue", issueRefID)
+		val := sdk.WorkIssueCommentUpdate{}
+		active := false
+		val.Set.Active = &active
+		if err := pipe.Write(sdk.NewWorkIssueCommentUpdate(customerID, integrationInstanceID, refID, refType, val)); err != nil {
+			return err
+		}
+	}
+	if len(current) == 0 {
+		if _, ok := project[issueID]; ok {
+			delete(project, issueID)
+			c.dirty[projectID] = true
+		}
+		return nil
+	}
+	project[issueID] = current
+	c.dirty[projectID] = true
+	return nil
+}
+
+// prune will forget the comments of any issues which are no longer in the project
+func (c *exportedComments) prune(projectID string, issueIDs []int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	project, err := c.load(projectID)
+	if err != nil {
+		return err
+	}
+	found := make(map[int64]bool)
+	for _, id := range issueIDs {
+		found[id] = true
+	}
+	for id := range project {
+		if !found[id] {
+			delete(project, id)
+			c.dirty[projectID] = true
+		}
+	}
+	return nil
+}
+
+// save will write the comments of each project which has changed to the state
+func (c *exportedComments) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for projectID := range c.dirty {
+		key := projectCommentsStateKeyPrefix + projectID
+		if len(c.projects[projectID]) == 0 {
+			if err := c.state.Delete(key); err != nil {
+				return fmt.Errorf("error removing project comments from state: %w", err)
+			}
+		} else if err := c.state.Set(key, encodeProjectComments(c.projects[projectID])); err != nil {
+			return fmt.Errorf("error saving project comments to state: %w", err)
+		}
+		delete(c.dirty, projectID)
+	}
+	return nil
 }
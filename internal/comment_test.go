@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/integration-sdk/agent"
+	"github.com/pinpt/integration-sdk/work"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestComments(count int) []comment {
+	comments := make([]comment, 0)
+	for n := 1; n <= count; n++ {
+		comments = append(comments, comment{
+			ID:      fmt.Sprintf("%d", 30000+n),
+			Author:  user{AccountID: "5c2b9f3e4b2c4a0d8a1b2c3d", DisplayName: "Test User"},
+			Created: "2020-10-01T10:00:00.000+0000",
+			Updated: "2020-10-01T10:00:00.000+0000",
+		})
+	}
+	return comments
+}
+
+func TestExportFetchesAllCommentsAndDeactivatesRemoved(t *testing.T) {
+	for _, deployment := range testDeployments {
+		t.Run(deployment, func(t *testing.T) {
+			assert := assert.New(t)
+			jira := newFakeJiraDeployment(deployment)
+			defer jira.Close()
+			jira.addProject("10000", "ABC", 2)
+			issue := &jira.issues["10000"][0]
+			comments := makeTestComments(120)
+			for n := range comments {
+				comments[n].Body = jira.content("comment " + comments[n].ID)
+			}
+			jira.setComments(issue, comments, 50)
+
+			integration := newMockIntegration()
+			state := newMockState()
+			export := newMockExport(jira.URL(), state, true)
+			assert.NoError(integration.Export(export))
+			exported := make(map[string]bool)
+			for _, object := range export.pipe.written {
+				if c, ok := object.(*sdk.WorkIssueComment); ok {
+					assert.Equal(sdk.NewWorkIssueID("1234", issue.ID, refType), c.IssueID)
+					assert.Contains(c.Body, "comment "+c.RefID)
+					exported[c.RefID] = true
+				}
+			}
+			assert.Len(exported, 120)
+			assert.True(state.Exists(projectCommentsStateKeyPrefix + "10000"))
+			assert.False(state.Exists(issueCommentsStateKeyPrefix + issue.ID))
+
+			// remove a comment that was embedded and one that was only in a later page
+			jira.setComments(issue, append(append(append([]comment{}, comments[:10]...), comments[11:100]...), comments[101:]...), 50)
+			export = newMockExport(jira.URL(), state, false)
+			assert.NoError(integration.Export(export))
+			deactivated := make([]string, 0)
+			var count int
+			for _, object := range export.pipe.written {
+				switch v := object.(type) {
+				case *sdk.WorkIssueComment:
+					count++
+				case *agent.UpdateData:
+					if v.Model == work.IssueCommentModelName.String() {
+						assert.EqualValues("false", v.Set["active"])
+						deactivated = append(deactivated, v.RefID)
+					}
+				}
+			}
+			assert.Equal(118, count)
+			assert.Equal([]string{comments[10].ID, comments[100].ID}, deactivated)
+		})
+	}
+}
+
+func TestEncodeProjectComments(t *testing.T) {
+	assert := assert.New(t)
+	comments := map[int64][]int64{10234: {30002, 30001}, 10001: {30010}}
+	val := encodeProjectComments(comments)
+	assert.Equal("7pt:n5m;6h:n5d,1", val)
+	decoded, err := decodeProjectComments(val)
+	assert.NoError(err)
+	assert.Equal(map[int64][]int64{10001: {30010}, 10234: {30001, 30002}}, decoded)
+	decoded, err = decodeProjectComments("")
+	assert.NoError(err)
+	assert.Empty(decoded)
+	_, err = decodeProjectComments("7pt")
+	assert.Error(err)
+}
+
+func TestExportMovesIssueCommentsToProject(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 2)
+	issue := &jira.issues["10000"][0]
+	comments := makeTestComments(3)
+	jira.setComments(issue, comments, 3)
+	// an export before the comments were kept by project had one more
+	state := newMockState()
+	assert.NoError(state.Set(issueCommentsStateKeyPrefix+issue.ID, []string{"30001", "30002", "30003", "30004"}))
+
+	export := newMockExport(jira.URL(), state, false)
+	assert.NoError(newMockIntegration().Export(export))
+	deactivated := make([]string, 0)
+	for _, object := range export.pipe.written {
+		if v, ok := object.(*agent.UpdateData); ok && v.Model == work.IssueCommentModelName.String() {
+			deactivated = append(deactivated, v.RefID)
+		}
+	}
+	assert.Equal([]string{"30004"}, deactivated)
+	assert.False(state.Exists(issueCommentsStateKeyPrefix + issue.ID))
+	var val string
+	_, err := state.Get(projectCommentsStateKeyPrefix+"10000", &val)
+	assert.NoError(err)
+	decoded, err := decodeProjectComments(val)
+	assert.NoError(err)
+	assert.Equal(map[int64][]int64{100000001: {30001, 30002, 30003}}, decoded)
+}
+
+func TestExportCommentsNotFound(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 2)
+	issue := &jira.issues["10000"][0]
+	jira.setComments(issue, makeTestComments(60), 50)
+	// the issue was deleted after the search so the comments api returns a 404
+	delete(jira.comments, issue.ID)
+
+	export := newMockExport(jira.URL(), newMockState(), true)
+	assert.NoError(newMockIntegration().Export(export))
+	var count int
+	for _, object := range export.pipe.written {
+		if _, ok := object.(*sdk.WorkIssueComment); ok {
+			count++
+		}
+	}
+	assert.Equal(50, count)
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const (
+	// configKeyIssueConcurrency is the number of issue search pages to fetch at the same time
+	configKeyIssueConcurrency = "issue_concurrency"
+	// configKeyIssueFilter is a jql clause which is ANDed into our searches to limit the issues which are exported
+	configKeyIssueFilter = "issue_filter"
+	// configKeyProjectTypes is a comma separated list of the types of projects to export
+	configKeyProjectTypes = "project_types"
+	// configKeyCustomFieldMappings is a json array of the custom fields to map onto issues, see customFieldMapping
+	configKeyCustomFieldMappings = "custom_field_mappings"
+	// configKeyMirrorAttachments turns on downloading the content of attachments for the agent to store
+	configKeyMirrorAttachments = "mirror_attachments"
+	// configKeyAttachmentMaxSize is the size in bytes of the biggest attachment to mirror
+	configKeyAttachmentMaxSize = "attachment_max_size"
+	// configKeyAttachmentContentTypes is a comma separated list of the content types to mirror, such as image/* or application/pdf
+	configKeyAttachmentContentTypes = "attachment_content_types"
+	// configKeyAttachmentExportBudget is the number of bytes of attachments to mirror in an export, 0 for no limit
+	configKeyAttachmentExportBudget = "attachment_export_budget"
+	// configKeyRestrictedContent is what to do with issues under a security level and restricted comments, see restrictionPolicy
+	configKeyRestrictedContent = "restricted_content"
+
+	defaultIssueConcurrency = 4
+	maxIssueConcurrency     = 20
+
+	defaultAttachmentMaxSize      = 10 << 20
+	defaultAttachmentExportBudget = 1 << 30
+)
+
+var defaultAttachmentContentTypes = []string{"image/*", "application/pdf", "text/plain"}
+
+// issueConcurrency returns the number of issue pages we should fetch in parallel for an instance
+func issueConcurrency(config sdk.Config) int {
+	found, c := config.GetInt(configKeyIssueConcurrency)
+	if !found || c == 0 {
+		return defaultIssueConcurrency
+	}
+	if c < 1 {
+		return 1
+	}
+	if c > maxIssueConcurrency {
+		return maxIssueConcurrency
+	}
+	return int(c)
+}
+
+// projectTypes returns the project types to export for an instance, which is only software projects unless configured
+func projectTypes(config sdk.Config) []string {
+	types := make([]string, 0)
+	found, val := config.GetString(configKeyProjectTypes)
+	if found {
+		for _, t := range strings.Split(val, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if validProjectTypes[t] && !sliceContains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return []string{projectTypeSoftware}
+	}
+	return types
+}
+
+// easyjson:skip
+type attachmentPolicy struct {
+	maxSize      int64
+	contentTypes []string
+	budget       int64
+}
+
+// attachmentMirrorPolicy returns which attachments to mirror for an instance, or nil if mirroring isn't turned on
+func attachmentMirrorPolicy(config sdk.Config) *attachmentPolicy {
+	if found, mirror := config.GetBool(configKeyMirrorAttachments); !found || !mirror {
+		return nil
+	}
+	policy := &attachmentPolicy{
+		maxSize: defaultAttachmentMaxSize,
+		budget:  defaultAttachmentExportBudget,
+	}
+	if found, size := config.GetInt(configKeyAttachmentMaxSize); found && size > 0 {
+		policy.maxSize = size
+	}
+	if found, budget := config.GetInt(configKeyAttachmentExportBudget); found {
+		policy.budget = budget
+	}
+	found, val := config.GetString(configKeyAttachmentContentTypes)
+	if found {
+		for _, t := range strings.Split(val, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && !sliceContains(policy.contentTypes, t) {
+				policy.contentTypes = append(policy.contentTypes, t)
+			}
+		}
+	}
+	if len(policy.contentTypes) == 0 {
+		policy.contentTypes = defaultAttachmentContentTypes
+	}
+	return policy
+}
+
+// restrictedContent returns what to do with restricted issues and comments for an instance, which is to export them
+// unless configured
+func restrictedContent(config sdk.Config) restrictionPolicy {
+	found, val := config.GetString(configKeyRestrictedContent)
+	if !found || strings.TrimSpace(val) == "" {
+		return restrictionPolicyExport
+	}
+	return restrictionPolicy(strings.ToLower(strings.TrimSpace(val)))
+}
+
+// validateRestrictedContent checks that the policy is one we know, so that restricted content isn't exported by mistake
+func validateRestrictedContent(policy restrictionPolicy) error {
+	switch policy {
+	case restrictionPolicyExport, restrictionPolicyRedact, restrictionPolicySkip:
+		return nil
+	}
+	return fmt.Errorf("unknown %s policy: %s", configKeyRestrictedContent, policy)
+}
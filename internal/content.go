@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pinpt/adf"
+	"github.com/pinpt/confluence"
+)
+
+// contentToHTML renders a description or comment body as html. v3 of the api sends them as ADF documents, while v2 and
+// webhooks send them as strings of wiki markup, so we go by what we got rather than which api it came from
+func contentToHTML(body json.RawMessage) (string, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || bytes.Equal(body, []byte("null")) {
+		return "", nil
+	}
+	if body[0] == '"' {
+		var markup string
+		if err := json.Unmarshal(body, &markup); err != nil {
+			return "", fmt.Errorf("error decoding wiki markup: %w", err)
+		}
+		if markup == "" {
+			return "", nil
+		}
+		return confluence.ParseToHTML([]byte(markup))
+	}
+	return adf.GenerateHTMLFromADF(body)
+}
+
+// contentValue returns the value to send for a description or comment of plain text, an ADF document for v3 of the api
+// and the text itself for v2 where it's wiki markup
+func contentValue(authConfig authConfig, text string) interface{} {
+	if !authConfig.usesADF() {
+		return text
+	}
+	return adf.Node{
+		Type:    "doc",
+		Version: 1,
+		Content: []adf.Node{
+			{
+				Type: "paragraph",
+				Content: []adf.Node{
+					{
+						Text: text,
+						Type: "text",
+					},
+				},
+			},
+		},
+	}
+}
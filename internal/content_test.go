@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pinpt/adf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentToHTML(t *testing.T) {
+	assert := assert.New(t)
+	html, err := contentToHTML(json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Looks good"}]}]}`))
+	assert.NoError(err)
+	assert.Equal("<p>Looks good</p>", html)
+	html, err = contentToHTML(json.RawMessage(`"h1. Release notes"`))
+	assert.NoError(err)
+	assert.Contains(html, "<h1>Release notes</h1>")
+	html, err = contentToHTML(json.RawMessage(`null`))
+	assert.NoError(err)
+	assert.Empty(html)
+	html, err = contentToHTML(json.RawMessage(`""`))
+	assert.NoError(err)
+	assert.Empty(html)
+}
+
+func TestContentValue(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("Looks good", contentValue(authConfig{APIPath: apiPathV2}, "Looks good"))
+	doc, ok := contentValue(authConfig{APIPath: apiPathV3}, "Looks good").(adf.Node)
+	assert.True(ok)
+	assert.Equal("doc", doc.Type)
+	assert.Equal("Looks good", doc.Content[0].Content[0].Text)
+}
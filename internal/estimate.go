@@ -0,0 +1,349 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+// estimateWindowDaysKey is the validate config key for how many days of issue updates to count, all of them if not set
+const estimateWindowDaysKey = "window_days"
+
+const (
+	// exportSetupCalls is roughly the number of calls an export makes before it fetches any projects
+	exportSetupCalls = 8
+	// projectCalls are the calls made for each project, for its issue types and create metadata
+	projectCalls = 2
+	// boardCalls are the calls made for each board, for its configuration, sprints and issues
+	boardCalls = 3
+	// sprintCalls are the calls made for each sprint, for the sprint and its issues
+	sprintCalls = 2
+	// estimateSampledBoards is the most boards whose sprints are counted, the sprints on any others are estimated
+	estimateSampledBoards = 20
+)
+
+// easyjson:skip
+type projectEstimate struct {
+	ID              string `json:"id"`
+	Key             string `json:"key"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	TotalIssues     int    `json:"total_issues"`
+	LastIssueUpdate string `json:"last_issue_update,omitempty"`
+	Issues          int    `json:"issues"`
+	Boards          int    `json:"boards"`
+	Sprints         int    `json:"sprints"`
+}
+
+// easyjson:skip
+type rateLimitHeaders struct {
+	limit           int
+	remaining       int
+	fillRate        int
+	intervalSeconds int
+}
+
+func (r *rateLimitHeaders) observe(header http.Header) {
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		r.limit = v
+	}
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		r.remaining = v
+	}
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-FillRate")); err == nil {
+		r.fillRate = v
+	}
+	if v, err := strconv.Atoi(header.Get("X-RateLimit-Interval-Seconds")); err == nil {
+		r.intervalSeconds = v
+	}
+}
+
+func (r rateLimitHeaders) observed() bool {
+	return r.limit > 0 || r.fillRate > 0
+}
+
+// easyjson:skip
+type exportEstimator struct {
+	i          *JiraIntegration
+	logger     sdk.Logger
+	authConfig authConfig
+	requests   int
+	latency    time.Duration
+	rateLimit  rateLimitHeaders
+}
+
+// get will fetch the url while recording how long it took and any rate limit headers
+func (e *exportEstimator) get(theurl string, qs url.Values, out interface{}) (*sdk.HTTPResponse, error) {
+	client := e.i.httpmanager.New(theurl, nil)
+	ts := time.Now()
+	r, err := client.Get(out, append(e.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...)
+	e.requests++
+	e.latency += time.Since(ts)
+	if r != nil {
+		e.rateLimit.observe(r.Headers)
+	}
+	return r, err
+}
+
+func (e *exportEstimator) averageLatency() time.Duration {
+	if e.requests == 0 {
+		return 0
+	}
+	return e.latency / time.Duration(e.requests)
+}
+
+// isProjectIncluded returns false if the project would be made inactive by the instance's inclusions or exclusions
+func isProjectIncluded(config sdk.Config, entityID string, p project) bool {
+	if config.Exclusions != nil && config.Exclusions.Matches(entityID, p.Key) {
+		return false
+	}
+	if config.Inclusions != nil {
+		return config.Inclusions.Matches(entityID, p.Name) || config.Inclusions.Matches(entityID, p.Key) || config.Inclusions.Matches(entityID, p.ID)
+	}
+	return true
+}
+
+func (e *exportEstimator) fetchProjects(config sdk.Config) ([]projectEstimate, error) {
+	types := projectTypes(config)
+	qs := make(url.Values)
+	qs.Set("expand", "insight")
+	qs.Set("typeKey", strings.Join(types, ","))
+	qs.Set("status", "live")
+	qs.Set("maxResults", "100")
+	projects := make([]projectEstimate, 0)
+	var count int
+	for {
+		qs.Set("startAt", strconv.Itoa(count))
+		var resp projectQueryResult
+		if _, err := e.get(e.authConfig.restURL("/project/search"), qs, &resp); err != nil {
+			return nil, fmt.Errorf("error fetching projects: %w", err)
+		}
+		for _, p := range resp.Projects {
+			count++
+			if !sliceContains(types, p.ProjectTypeKey) || !isProjectIncluded(config, e.authConfig.APIURL, p) {
+				continue
+			}
+			estimate := projectEstimate{
+				ID:          p.ID,
+				Key:         p.Key,
+				Name:        p.Name,
+				Type:        p.ProjectTypeKey,
+				TotalIssues: -1, // unknown without the insight
+			}
+			if p.Insight != nil {
+				estimate.TotalIssues = p.Insight.TotalIssueCount
+				estimate.LastIssueUpdate = p.Insight.LastIssueUpdateTime
+			}
+			projects = append(projects, estimate)
+		}
+		if len(resp.Projects) == 0 || count >= resp.Total {
+			break
+		}
+	}
+	return projects, nil
+}
+
+// countIssues returns the number of issues a search for the project would return, without fetching any of them
+func (e *exportEstimator) countIssues(projectID string, fromTime time.Time, filter string) (int, error) {
+	qs := make(url.Values)
+	qs.Set("jql", issueSearchJQL([]string{projectID}, fromTime, filter))
+	qs.Set("fields", "id")
+	qs.Set("maxResults", "0")
+	var resp issueQueryResult
+	if _, err := e.get(e.authConfig.restURL("/search"), qs, &resp); err != nil {
+		return 0, fmt.Errorf("error counting issues for project %s: %w", projectID, err)
+	}
+	return resp.Total, nil
+}
+
+// fetchProjectBoards returns the number of boards for a project and the ids of up to max of them, from a single page
+func (e *exportEstimator) fetchProjectBoards(projectKey string, max int) (int, []int, error) {
+	qs := make(url.Values)
+	qs.Set("projectKeyOrId", projectKey)
+	qs.Set("maxResults", strconv.Itoa(max))
+	var resp struct {
+		Total  int           `json:"total"`
+		IsLast bool          `json:"isLast"`
+		Values []boardSource `json:"values"`
+	}
+	if _, err := e.get(sdk.JoinURL(e.authConfig.APIURL, "/rest/agile/1.0/board"), qs, &resp); err != nil {
+		return 0, nil, fmt.Errorf("error fetching agile boards for project %s: %w", projectKey, err)
+	}
+	ids := make([]int, 0, len(resp.Values))
+	for _, board := range resp.Values {
+		ids = append(ids, board.ID)
+	}
+	total := resp.Total
+	if total < len(ids) {
+		total = len(ids)
+	}
+	return total, ids, nil
+}
+
+// fetchSprintIDs returns the ids of the sprints in the first page for a board, and how many more there are
+func (e *exportEstimator) fetchSprintIDs(boardID int) ([]int, int, error) {
+	qs := make(url.Values)
+	qs.Set("maxResults", "50")
+	qs.Set("state", "future,active,closed")
+	var resp struct {
+		Total  int  `json:"total"`
+		IsLast bool `json:"isLast"`
+		Values []struct {
+			ID int `json:"id"`
+		} `json:"values"`
+	}
+	r, err := e.get(sdk.JoinURL(e.authConfig.APIURL, fmt.Sprintf("/rest/agile/1.0/board/%d/sprint", boardID)), qs, &resp)
+	if r != nil && (r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusBadRequest) {
+		// kanban boards don't support sprints
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching agile sprints: %w", err)
+	}
+	ids := make([]int, 0, len(resp.Values))
+	for _, s := range resp.Values {
+		ids = append(ids, s.ID)
+	}
+	var more int
+	if !resp.IsLast && resp.Total > len(ids) {
+		more = resp.Total - len(ids)
+	}
+	return ids, more, nil
+}
+
+// estimateAPICalls returns a lower bound on the calls an export will make, split into the issue search pages which are
+// fetched concurrently and everything else. issues with more changelogs or comments than jira embeds need more calls
+func estimateAPICalls(projects []projectEstimate, boards int, sprints int) (int, int) {
+	var issuePages int
+	for _, p := range projects {
+		pages := (p.Issues + issuesPageSize - 1) / issuesPageSize
+		if pages == 0 {
+			pages = 1
+		}
+		issuePages += pages
+	}
+	other := exportSetupCalls + (len(projects)+99)/100 + len(projects)*projectCalls
+	other += (boards+99)/100 + boards*boardCalls + sprints*sprintCalls
+	return issuePages, other
+}
+
+// estimateExportDuration uses the latency we saw to estimate how long the calls will take, or how long the rate limit
+// will let them take if that is longer
+func estimateExportDuration(issuePages int, other int, concurrency int, latency time.Duration, rateLimit rateLimitHeaders) time.Duration {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	d := time.Duration(other)*latency + time.Duration((issuePages+concurrency-1)/concurrency)*latency
+	calls := issuePages + other
+	if rateLimit.fillRate > 0 && rateLimit.intervalSeconds > 0 && calls > rateLimit.remaining {
+		// once the remaining requests are used we can only go as fast as they are refilled
+		throttled := time.Duration(calls-rateLimit.remaining) * time.Duration(rateLimit.intervalSeconds) * time.Second / time.Duration(rateLimit.fillRate)
+		if throttled > d {
+			d = throttled
+		}
+	}
+	return d
+}
+
+// estimateExport will return the size of an export of the instance, and roughly how long it will take
+func (i *JiraIntegration) estimateExport(logger sdk.Logger, config sdk.Config, authConfig authConfig) (map[string]interface{}, error) {
+	e := &exportEstimator{i: i, logger: logger, authConfig: authConfig}
+	_, window := config.GetInt(estimateWindowDaysKey)
+	days := int(window)
+	var fromTime time.Time
+	if days > 0 {
+		fromTime = time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	projects, err := e.fetchProjects(config)
+	if err != nil {
+		return nil, err
+	}
+	filter := issueFilter(config)
+	var issues int
+	for n := range projects {
+		p := &projects[n]
+		if p.TotalIssues == 0 {
+			continue // the export skips these
+		}
+		if p.Issues, err = e.countIssues(p.ID, fromTime, filter); err != nil {
+			return nil, err
+		}
+		issues += p.Issues
+	}
+	var boards, sprints int
+	if authConfig.SupportsAgileAPI && len(projects) > 0 {
+		// only the sprints of the first boards are counted, the rest are estimated from them
+		var sampledBoards, sampledSprints int
+		unsampled := make([]*projectEstimate, 0)
+		for n := range projects {
+			p := &projects[n]
+			total, ids, err := e.fetchProjectBoards(p.Key, estimateSampledBoards)
+			if err != nil {
+				return nil, err
+			}
+			p.Boards = total
+			boards += total
+			if len(ids) > estimateSampledBoards-sampledBoards {
+				ids = ids[:estimateSampledBoards-sampledBoards]
+			}
+			if len(ids) == 0 {
+				if total > 0 {
+					unsampled = append(unsampled, p)
+				}
+				continue
+			}
+			// sprints can be on more than one board so only count them once
+			found := make(map[int]bool)
+			var count int
+			for _, boardID := range ids {
+				sprintIDs, more, err := e.fetchSprintIDs(boardID)
+				if err != nil {
+					return nil, err
+				}
+				for _, id := range sprintIDs {
+					if !found[id] {
+						found[id] = true
+						count++
+					}
+				}
+				count += more
+			}
+			sampledBoards += len(ids)
+			sampledSprints += count
+			p.Sprints = count * total / len(ids)
+			sprints += p.Sprints
+		}
+		for _, p := range unsampled {
+			if sampledBoards > 0 {
+				p.Sprints = sampledSprints * p.Boards / sampledBoards
+				sprints += p.Sprints
+			}
+		}
+	}
+	issuePages, other := estimateAPICalls(projects, boards, sprints)
+	duration := estimateExportDuration(issuePages, other, issueConcurrency(config), e.averageLatency(), e.rateLimit)
+	sdk.LogDebug(logger, "estimated export", "projects", len(projects), "issues", issues, "boards", boards, "sprints", sprints, "calls", issuePages+other, "duration", duration)
+	result := map[string]interface{}{
+		"projects":         projects,
+		"window_days":      days,
+		"issues":           issues,
+		"boards":           boards,
+		"sprints":          sprints,
+		"api_calls":        issuePages + other,
+		"duration_seconds": int64(duration.Seconds()),
+	}
+	if e.rateLimit.observed() {
+		result["rate_limit"] = map[string]interface{}{
+			"limit":            e.rateLimit.limit,
+			"remaining":        e.rateLimit.remaining,
+			"fill_rate":        e.rateLimit.fillRate,
+			"interval_seconds": e.rateLimit.intervalSeconds,
+		}
+	}
+	return result, nil
+}
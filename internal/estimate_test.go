@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateAPICalls(t *testing.T) {
+	assert := assert.New(t)
+	projects := []projectEstimate{{Issues: 250}, {Issues: 0}, {Issues: 100}}
+	issuePages, other := estimateAPICalls(projects, 2, 5)
+	assert.Equal(3+1+1, issuePages)
+	assert.Equal(exportSetupCalls+1+3*projectCalls+1+2*boardCalls+5*sprintCalls, other)
+}
+
+func TestEstimateExportDuration(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(20*time.Second, estimateExportDuration(40, 10, 4, time.Second, rateLimitHeaders{}))
+	rateLimit := rateLimitHeaders{limit: 100, remaining: 100, fillRate: 10, intervalSeconds: 1}
+	assert.Equal(20*time.Second, estimateExportDuration(40, 10, 4, time.Second, rateLimit))
+	// 150 calls is 50 more than the 100 remaining, which are refilled at 10 a second
+	assert.Equal(5*time.Second, estimateExportDuration(140, 10, 4, time.Millisecond, rateLimit))
+}
+
+func TestValidateEstimateExport(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 250)
+	jira.addProject("10001", "DEF", 5)
+	assert.NoError(json.Unmarshal([]byte(`{"totalIssueCount":250,"lastIssueUpdateTime":"2020-10-02T10:00:00.000+0000"}`), &jira.projects[0].Insight))
+	jira.addBoard(1, 10000, "ABC")
+	jira.addBoard(2, 10000, "ABC")
+	jira.sprints[1] = []int{1, 2, 3}
+	jira.sprints[2] = []int{3, 4}
+	jira.headers["X-RateLimit-Limit"] = "100"
+	jira.headers["X-RateLimit-Remaining"] = "90"
+
+	result, err := newMockIntegration().Validate(newMockValidate(jira.URL(), map[string]interface{}{"action": EstimateExport, estimateWindowDaysKey: "30"}))
+	assert.NoError(err)
+	projects := result["projects"].([]projectEstimate)
+	assert.Len(projects, 2)
+	assert.Equal(projectEstimate{ID: "10000", Key: "ABC", Name: "ABC", Type: "software", TotalIssues: 250, LastIssueUpdate: "2020-10-02T10:00:00.000+0000", Issues: 250, Boards: 2, Sprints: 4}, projects[0])
+	assert.Equal(projectEstimate{ID: "10001", Key: "DEF", Name: "DEF", Type: "software", TotalIssues: -1, Issues: 5}, projects[1])
+	assert.Equal(30, result["window_days"])
+	assert.Equal(255, result["issues"])
+	assert.Equal(2, result["boards"])
+	assert.Equal(4, result["sprints"])
+	issuePages, other := estimateAPICalls(projects, 2, 4)
+	assert.Equal(issuePages+other, result["api_calls"])
+	assert.Equal(map[string]interface{}{"limit": 100, "remaining": 90, "fill_rate": 0, "interval_seconds": 0}, result["rate_limit"])
+	assert.Contains(jira.jqls[0], "project in (10000) AND (created >=")
+}
+
+func TestValidateEstimateExportSamplesSprints(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.addProject("10001", "DEF", 5)
+	// only the sprints of the first estimateSampledBoards boards are fetched
+	for n := 1; n <= estimateSampledBoards+10; n++ {
+		jira.addBoard(n, 10000, "ABC")
+		jira.sprints[n] = []int{n*10 + 1, n*10 + 2}
+	}
+	for n := 1; n <= 5; n++ {
+		jira.addBoard(100+n, 10001, "DEF")
+	}
+
+	result, err := newMockIntegration().Validate(newMockValidate(jira.URL(), map[string]interface{}{"action": EstimateExport}))
+	assert.NoError(err)
+	projects := result["projects"].([]projectEstimate)
+	assert.Equal(estimateSampledBoards+10, projects[0].Boards)
+	assert.Equal(2*(estimateSampledBoards+10), projects[0].Sprints)
+	assert.Equal(5, projects[1].Boards)
+	assert.Equal(10, projects[1].Sprints)
+	assert.Equal(estimateSampledBoards+15, result["boards"])
+	assert.Equal(2*(estimateSampledBoards+15), result["sprints"])
+}
@@ -14,54 +14,14 @@ import (
 	"github.com/pinpt/agent/v4/sdk"
 )
 
-func (i *JiraIntegration) checkForRateLimit(logger sdk.Logger, control sdk.Control, customerID string, rerr error, header http.Header) error {
-	if ok, dur := sdk.IsRateLimitError(rerr); ok {
-		// pause until we are no longer rate limited
-		sdk.LogInfo(logger, "rate limited", "until", time.Now().Add(dur), "customer_id", customerID)
-		time.Sleep(dur)
-		sdk.LogInfo(logger, "rate limit wake up", "customer_id", customerID)
-		// send a resume now that we're no longer rate limited
-		if err := control.Resumed(); err != nil {
-			return err
-		}
-		return nil
-	}
-
-	//TODO: check this against the new HTTP changes
-
-	// check for rate limit headers
-	limit := header.Get("X-RateLimit-Limit")
-	total := header.Get("X-RateLimit-Remaining")
-	var shouldPause bool
-	if limit != "" && total != "" {
-		l, _ := strconv.ParseInt(limit, 10, 32)
-		t, _ := strconv.ParseInt(total, 10, 32)
-		shouldPause = float32(t)*.8 >= float32(l)
-	}
-	if shouldPause {
-		dur := time.Minute * 5
-		resetAt := time.Now().Add(dur)
-		if err := control.Paused(resetAt); err != nil {
-			return err
-		}
-		// pause until we are no longer rate limited
-		sdk.LogInfo(logger, "rate limited", "until", resetAt, "customer_id", customerID)
-		time.Sleep(dur)
-		sdk.LogInfo(logger, "rate limit wake up", "customer_id", customerID)
-		// send a resume now that we're no longer rate limited
-		if err := control.Resumed(); err != nil {
-			return err
-		}
-	}
-	return rerr
-}
-
 func (i *JiraIntegration) fetchPriorities(state *state) error {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/priority")
+	theurl := state.authConfig.restURL("/priority")
 	client := i.httpmanager.New(theurl, nil)
 	resp := make([]issuePriority, 0)
 	ts := time.Now()
-	r, err := client.Get(&resp, state.authConfig.Middleware...)
+	if _, err := client.Get(&resp, state.authConfig.Middleware...); err != nil {
+		return err
+	}
 	customerID := state.export.CustomerID()
 	for _, p := range resp {
 		priority, err := p.ToModel(customerID, state.integrationInstanceID)
@@ -73,19 +33,18 @@ func (i *JiraIntegration) fetchPriorities(state *state) error {
 		}
 		state.stats.incPriority()
 	}
-	if err := i.checkForRateLimit(state.logger, state.export, customerID, err, r.Headers); err != nil {
-		return err
-	}
 	sdk.LogDebug(state.logger, "fetched priorities", "len", len(resp), "duration", time.Since(ts))
 	return nil
 }
 
 func (i *JiraIntegration) fetchTypes(state *state) error {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/issuetype")
+	theurl := state.authConfig.restURL("/issuetype")
 	client := i.httpmanager.New(theurl, nil)
 	resp := make([]issueType, 0)
 	ts := time.Now()
-	r, err := client.Get(&resp, state.authConfig.Middleware...)
+	if _, err := client.Get(&resp, state.authConfig.Middleware...); err != nil {
+		return err
+	}
 	customerID := state.export.CustomerID()
 	for _, t := range resp {
 		issuetype, err := t.ToModel(customerID, state.integrationInstanceID)
@@ -97,23 +56,16 @@ func (i *JiraIntegration) fetchTypes(state *state) error {
 		}
 		state.stats.incType()
 	}
-	if err := i.checkForRateLimit(state.logger, state.export, customerID, err, r.Headers); err != nil {
-		return err
-	}
 	sdk.LogDebug(state.logger, "fetched issue types", "len", len(resp), "duration", time.Since(ts))
 	return nil
 }
 
 func (i *JiraIntegration) fetchCustomFields(logger sdk.Logger, control sdk.Control, customerID string, authConfig authConfig) (map[string]customField, error) {
-	theurl := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/field")
+	theurl := authConfig.restURL("/field")
 	client := i.httpmanager.New(theurl, nil)
 	resp := make([]customFieldQueryResult, 0)
 	ts := time.Now()
-	r, err := client.Get(&resp, authConfig.Middleware...)
-	if err != nil {
-		return nil, err
-	}
-	if err := i.checkForRateLimit(logger, control, customerID, err, r.Headers); err != nil {
+	if _, err := client.Get(&resp, authConfig.Middleware...); err != nil {
 		return nil, err
 	}
 	customfields := map[string]customField{}
@@ -125,14 +77,91 @@ func (i *JiraIntegration) fetchCustomFields(logger sdk.Logger, control sdk.Contr
 			field.ID = r.ID
 		}
 		field.Name = r.Name
+		field.Type = r.Schema.Type
+		field.Items = r.Schema.Items
+		field.Custom = r.Schema.Custom
 		customfields[field.ID] = field
 	}
 	sdk.LogDebug(logger, "fetched custom fields", "len", len(resp), "duration", time.Since(ts))
 	return customfields, nil
 }
 
+const (
+	customFieldsStateKey    = "custom_fields"
+	customFieldsCacheExpiry = time.Hour
+)
+
+// fetchCachedCustomFields returns the custom fields, which are kept in the state for a while since every issue webhook
+// needs them and they rarely change. they don't have the instance's mappings applied
+func (i *JiraIntegration) fetchCachedCustomFields(logger sdk.Logger, control sdk.Control, state sdk.State, customerID string, authConfig authConfig) (map[string]customField, error) {
+	customfields := make(map[string]customField)
+	if ok, err := state.Get(customFieldsStateKey, &customfields); ok && err == nil {
+		return customfields, nil
+	}
+	customfields, err := i.fetchCustomFields(logger, control, customerID, authConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := cacheCustomFields(state, customfields); err != nil {
+		return nil, err
+	}
+	return customfields, nil
+}
+
+// cacheCustomFields saves the custom fields for fetchCachedCustomFields, they must not have the mappings applied yet
+func cacheCustomFields(state sdk.State, customfields map[string]customField) error {
+	if err := state.SetWithExpires(customFieldsStateKey, customfields, customFieldsCacheExpiry); err != nil {
+		return fmt.Errorf("error saving custom fields to state: %w", err)
+	}
+	return nil
+}
+
+const customFieldIDsStateKey = "custom_field_ids"
+
+// findCustomFieldIDs returns the ids of the custom fields we know about, with the Story Points field of company-managed
+// projects being the one their boards estimate with when the agile api can tell us
+func (i *JiraIntegration) findCustomFieldIDs(logger sdk.Logger, customerID string, integrationInstanceID string, authConfig authConfig, customfields map[string]customField) customFieldIDs {
+	ids := findCustomFieldIDs(customfields)
+	if !authConfig.SupportsAgileAPI {
+		return ids
+	}
+	api := newAgileAPI(logger, authConfig, customerID, integrationInstanceID, i.httpmanager)
+	fieldID, err := api.fetchEstimationFieldID(ids.StoryPointEstimate)
+	if err != nil {
+		// the field found by its english name is the best we can do
+		sdk.LogWarn(logger, "error finding the story points field from the boards", "err", err)
+		return ids
+	}
+	if fieldID != "" {
+		ids.StoryPoints = fieldID
+	}
+	return ids
+}
+
+// fetchCachedCustomFieldIDs returns the ids of the custom fields we know about, which are kept in the state along with
+// the custom fields for the webhooks
+func (i *JiraIntegration) fetchCachedCustomFieldIDs(logger sdk.Logger, state sdk.State, customerID string, integrationInstanceID string, authConfig authConfig, customfields map[string]customField) (customFieldIDs, error) {
+	var ids customFieldIDs
+	if ok, err := state.Get(customFieldIDsStateKey, &ids); ok && err == nil {
+		return ids, nil
+	}
+	ids = i.findCustomFieldIDs(logger, customerID, integrationInstanceID, authConfig, customfields)
+	if err := cacheCustomFieldIDs(state, ids); err != nil {
+		return ids, err
+	}
+	return ids, nil
+}
+
+// cacheCustomFieldIDs saves the ids for fetchCachedCustomFieldIDs
+func cacheCustomFieldIDs(state sdk.State, ids customFieldIDs) error {
+	if err := state.SetWithExpires(customFieldIDsStateKey, ids, customFieldsCacheExpiry); err != nil {
+		return fmt.Errorf("error saving custom field ids to state: %w", err)
+	}
+	return nil
+}
+
 func (i *JiraIntegration) fetchIssueCreateMeta(state *state, projectIDs []string) ([]projectIssueCreateMeta, error) {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/issue/createmeta")
+	theurl := state.authConfig.restURL("/issue/createmeta")
 	client := i.httpmanager.New(theurl, nil)
 	queryParams := make(url.Values)
 	if len(projectIDs) > 0 {
@@ -140,8 +169,7 @@ func (i *JiraIntegration) fetchIssueCreateMeta(state *state, projectIDs []string
 	}
 	queryParams.Set("expand", "projects.issuetypes.fields")
 	var resp issueCreateMeta
-	r, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
-	if err := i.checkForRateLimit(state.logger, state.export, state.export.CustomerID(), err, r.Headers); err != nil {
+	if _, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...); err != nil {
 		return nil, err
 	}
 	return resp.Projects, nil
@@ -149,16 +177,18 @@ func (i *JiraIntegration) fetchIssueCreateMeta(state *state, projectIDs []string
 
 const savedPreviousProjectsStateKey = "previous_projects"
 
-func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error) {
+// fetchProjectsPaginated returns the active projects to export and which of those weren't active in the previous export
+func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, []string, error) {
 	resolutions, err := i.fetchIssueResolutions(state)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/project/search")
+	theurl := state.authConfig.restURL("/project/search")
 	client := i.httpmanager.New(theurl, nil)
 	queryParams := make(url.Values)
 	setProjectExpand(queryParams)
-	queryParams.Set("typeKey", "software")
+	types := projectTypes(state.config)
+	queryParams.Set("typeKey", strings.Join(types, ","))
 	queryParams.Set("status", "live")
 	queryParams.Set("maxResults", "100") // 100 is the max, 50 is the default
 	var count int
@@ -171,22 +201,24 @@ func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error)
 	if state.export.State().Exists(savedPreviousProjectsStateKey) {
 		hasPreviousProjects = true
 		if _, err := state.export.State().Get(savedPreviousProjectsStateKey, &previousProjects); err != nil {
-			return nil, fmt.Errorf("error fetching previous projects state: %w", err)
+			return nil, nil, fmt.Errorf("error fetching previous projects state: %w", err)
 		}
 	}
 	for {
 		queryParams.Set("startAt", strconv.Itoa(count))
 		var resp projectQueryResult
 		ts := time.Now()
-		r, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
-		if err := i.checkForRateLimit(state.logger, state.export, customerID, err, r.Headers); err != nil {
-			return nil, err
+		if _, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...); err != nil {
+			return nil, nil, err
+		}
+		if len(resp.Projects) == 0 {
+			break
 		}
 		sdk.LogDebug(state.logger, "fetched projects", "len", len(resp.Projects), "total", resp.Total, "count", count, "first", resp.Projects[0].Key, "last", resp.Projects[len(resp.Projects)-1].Key, "duration", time.Since(ts))
 		for _, p := range resp.Projects {
 			count++
-			if p.ProjectTypeKey != "software" {
-				sdk.LogInfo(state.logger, "skipping project which isn't a software type", "key", p.Key)
+			if !sliceContains(types, p.ProjectTypeKey) {
+				sdk.LogInfo(state.logger, "skipping project which isn't one of the configured types", "key", p.Key, "type", p.ProjectTypeKey)
 				continue
 			}
 			if p.Insight != nil {
@@ -203,11 +235,11 @@ func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error)
 			}
 			issueTypes, err := i.fetchIssueTypesForProject(state, p.ID)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			project, err := p.ToModel(customerID, state.integrationInstanceID, state.authConfig.WebsiteURL, issueTypes, resolutions)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			entityID := state.authConfig.APIURL
 			if state.config.Exclusions != nil {
@@ -237,12 +269,12 @@ func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error)
 				}
 				capability, err := i.createProjectCapability(state.logger, state.export.State(), p, project, getCreateMeta, state.historical)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				if capability != nil {
 					// possible to be nil if already processed
 					if err := state.pipe.Write(capability); err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -265,15 +297,22 @@ func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error)
 	// we have to do this after we pull all the projects so we can determine if we have old projects
 	// that are no longer active
 	keys := make([]string, 0)
+	newKeys := make([]string, 0)
 	var active int
 	for key, project := range savedProjects {
 		if err := state.pipe.Write(project); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if project.Active {
 			keys = append(keys, key)
 			state.stats.incProject()
 			active++
+			if hasPreviousProjects {
+				// either newly included or included again after being excluded
+				if previous := previousProjects[key]; previous == nil || !previous.Active {
+					newKeys = append(newKeys, key)
+				}
+			}
 		}
 	}
 
@@ -288,11 +327,11 @@ func (i *JiraIntegration) fetchProjectsPaginated(state *state) ([]string, error)
 
 	// save the state so we can check the next time
 	if err := state.export.State().Set(savedPreviousProjectsStateKey, savedProjects); err != nil {
-		return nil, fmt.Errorf("error saving projects state: %w", err)
+		return nil, nil, fmt.Errorf("error saving projects state: %w", err)
 	}
 
-	sdk.LogInfo(state.logger, "export projects completed", "duration", time.Since(started), "count", len(savedProjects), "active", active)
-	return keys, nil
+	sdk.LogInfo(state.logger, "export projects completed", "duration", time.Since(started), "count", len(savedProjects), "active", active, "new", len(newKeys))
+	return keys, newKeys, nil
 }
 
 type issueTransitionSource struct {
@@ -300,14 +339,14 @@ type issueTransitionSource struct {
 }
 
 func (i *JiraIntegration) fetchIssueTransitions(logger sdk.Logger, control sdk.Control, authConfig authConfig, customerID string, issueRefID string) ([]sdk.WorkIssueTransitions, error) {
-	theurl := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/issue", issueRefID, "/transitions")
+	theurl := authConfig.restURL("/issue", issueRefID, "/transitions")
 	client := i.httpmanager.New(theurl, nil)
 	params := url.Values{}
 	params.Add("expand", "transitions")
 	var resp issueTransitionSource
 	r, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(params))...)
-	if err := i.checkForRateLimit(logger, control, customerID, err, r.Headers); err != nil {
-		if r.StatusCode == http.StatusNotFound {
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
 			sdk.LogWarn(logger, "transitions endpoint returned 404 for issue", "error_body", string(r.Body), "issue", issueRefID)
 			return nil, nil
 		}
@@ -344,83 +383,274 @@ func toIssueError(responseBody []byte) (issuesErr, bool) {
 	return ie, true
 }
 
-func issueSearchJQL(projectKeys []string, fromTime time.Time) string {
+func issueSearchJQL(projectKeys []string, fromTime time.Time, filter string) string {
 	jql := "project in (" + strings.Join(projectKeys, ",") + ") "
 	if !fromTime.IsZero() {
 		s := relativeDuration(time.Since(fromTime))
 		jql += fmt.Sprintf(`AND (created >= "%s" or updated >= "%s") `, s, s)
 	}
+	jql = andIssueFilter(jql, filter)
 	jql += "ORDER BY updated DESC" // search for the most recent changes first
 	return jql
 }
 
+// issueSearchResumableJQL returns a query with a stable order so that a search can be continued after afterKey
+func issueSearchResumableJQL(projectKeys []string, afterKey string, filter string) string {
+	jql := "project in (" + strings.Join(projectKeys, ",") + ") "
+	if afterKey != "" {
+		jql += fmt.Sprintf(`AND key > "%s" `, afterKey)
+	}
+	jql = andIssueFilter(jql, filter)
+	jql += "ORDER BY key ASC"
+	return jql
+}
+
+// easyjson:skip
+type issuePage struct {
+	startAt  int
+	resp     issueQueryResult
+	duration time.Duration
+}
+
+func (i *JiraIntegration) fetchIssuesPage(state *state, client sdk.HTTPClient, params url.Values, startAt int) (*issuePage, *sdk.HTTPResponse, error) {
+	// copy the params since pages can be fetched concurrently
+	queryParams := make(url.Values)
+	for k, v := range params {
+		queryParams[k] = v
+	}
+	queryParams.Set("startAt", strconv.Itoa(startAt))
+	page := &issuePage{startAt: startAt}
+	ts := time.Now()
+	r, err := client.Get(&page.resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
+	if err != nil {
+		return nil, r, err
+	}
+	// the search only includes some of the changelogs and comments so fetch the rest for any issues with more
+	for n := range page.resp.Issues {
+		if err := i.fetchTruncatedIssueData(state.logger, state.export, state.authConfig, &page.resp.Issues[n]); err != nil {
+			return nil, r, err
+		}
+	}
+	page.duration = time.Since(ts)
+	return page, r, nil
+}
+
+func (i *JiraIntegration) processIssuesPage(state *state, page *issuePage, customfields map[string]customField) error {
+	customerID := state.export.CustomerID()
+	resp := page.resp
+	toprocess := make([]issueSource, 0)
+	for _, i := range resp.Issues {
+		// since we're coming in out of order, try and reduce ref fetches
+		if state.issueIDManager.markProcessed(i.Key, i.ID) {
+			toprocess = append(toprocess, i)
+		}
+	}
+	// only process issues that haven't already been processed before (given recursion)
+	for _, i := range toprocess {
+		issue, comments, err := i.ToModel(customerID, state.integrationInstanceID, state.issueIDManager, state.sprintManager, state.userManager, customfields, state.issueIDManager.fieldIDs, state.authConfig.WebsiteURL, state.restriction, true)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			// it's restricted, so make sure it and its comments are removed if we had exported them
+			if err := state.pipe.Write(sdk.NewWorkIssueDeactivate(customerID, state.integrationInstanceID, i.ID, refType)); err != nil {
+				return err
+			}
+			if err := state.comments.deactivateRemoved(state.logger, state.pipe, customerID, state.integrationInstanceID, i.projectID(), i.ID, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		state.attachments.mirrorIssue(issue, i.redacted(state.restriction))
+		if err := state.pipe.Write(issue); err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			if err := state.pipe.Write(comment); err != nil {
+				return err
+			}
+			state.stats.incComment()
+		}
+		if err := state.comments.deactivateRemoved(state.logger, state.pipe, customerID, state.integrationInstanceID, i.projectID(), issue.RefID, comments); err != nil {
+			return err
+		}
+		state.stats.incIssue()
+	}
+	if len(resp.Issues) > 0 {
+		sdk.LogDebug(state.logger, "fetched issues", "len", len(resp.Issues), "total", resp.Total, "count", page.startAt, "first", resp.Issues[0].Key, "last", resp.Issues[len(resp.Issues)-1].Key, "duration", page.duration)
+	} else {
+		sdk.LogDebug(state.logger, "fetched issues", "len", len(resp.Issues), "total", resp.Total, "count", page.startAt, "duration", page.duration)
+	}
+	return nil
+}
+
+const issuesPageSize = 100 // 100 is the max, 50 is the default
+
 func (i *JiraIntegration) fetchIssuesPaginated(state *state, fromTime time.Time, customfields map[string]customField, projectKeys []string) error {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/search")
+	jql := func(projectKeys []string) string {
+		return issueSearchJQL(projectKeys, fromTime, state.issueFilter)
+	}
+	return i.searchIssuesPaginated(state, customfields, projectKeys, jql, nil)
+}
+
+// fetchIssuesIncremental will export the changes to each project since it was last exported, and all of the issues
+// for projects which are new to the export
+func (i *JiraIntegration) fetchIssuesIncremental(state *state, watermarks *projectWatermarks, exportStarted time.Time, customfields map[string]customField, projectKeys []string, newProjectKeys []string) error {
+	if len(newProjectKeys) > 0 {
+		sdk.LogInfo(state.logger, "will backfill the history of newly active projects", "projects", newProjectKeys)
+	}
+	for _, group := range groupProjectsByWatermark(watermarks, projectKeys, newProjectKeys) {
+		if group.fromTime.IsZero() {
+			sdk.LogInfo(state.logger, "exporting all issues for projects", "projects", group.projectKeys)
+		} else {
+			sdk.LogInfo(state.logger, "exporting issues for projects from a specific timestamp", "projects", group.projectKeys, "time", group.fromTime)
+		}
+		if err := i.fetchIssuesPaginated(state, group.fromTime, customfields, group.projectKeys); err != nil {
+			return err
+		}
+		// save as we go so a failure in a later group doesn't cause these to be exported again
+		if err := watermarks.set(group.projectKeys, exportStarted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchIssuesResumable will export the issues for each project in key order, recording our progress in the checkpoint
+// so that an interrupted export can continue from the last issue it exported
+func (i *JiraIntegration) fetchIssuesResumable(state *state, checkpoint *exportCheckpoint, customfields map[string]customField, projectKeys []string) error {
+	for _, projectKey := range projectKeys {
+		if checkpoint.isProjectDone(projectKey) {
+			sdk.LogDebug(state.logger, "skipping project since its issues were already exported", "project", projectKey)
+			continue
+		}
+		projectKey := projectKey
+		afterKey := checkpoint.issueCursor(projectKey)
+		if afterKey != "" {
+			sdk.LogInfo(state.logger, "resuming export of project issues", "project", projectKey, "after", afterKey)
+		}
+		jql := func(projectKeys []string) string {
+			return issueSearchResumableJQL(projectKeys, afterKey, state.issueFilter)
+		}
+		onPage := func(page *issuePage) error {
+			if len(page.resp.Issues) == 0 {
+				return nil
+			}
+			// send the issues on before recording them as exported so a resume can't skip any still buffered
+			if err := state.pipe.Flush(); err != nil {
+				return err
+			}
+			return checkpoint.setIssueCursor(projectKey, page.resp.Issues[len(page.resp.Issues)-1].Key)
+		}
+		if err := i.searchIssuesPaginated(state, customfields, []string{projectKey}, jql, onPage); err != nil {
+			return err
+		}
+		if err := checkpoint.markProjectDone(projectKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchIssuesPaginated will export all the issues matching the jql built for projectKeys. if not nil, onPage is called after each
+// page of issues has been processed
+func (i *JiraIntegration) searchIssuesPaginated(state *state, customfields map[string]customField, projectKeys []string, jql func(projectKeys []string) string, onPage func(page *issuePage) error) error {
+	theurl := state.authConfig.restURL("/search")
 	client := i.httpmanager.New(theurl, nil)
 	queryParams := make(url.Values)
 	queryParams.Set("expand", "changelog,fields,comments,transitions")
-	queryParams.Set("fields", "*navigable,attachment")
-	queryParams.Set("jql", issueSearchJQL(projectKeys, fromTime))
-	queryParams.Set("maxResults", "100") // 100 is the max, 50 is the default
-	var count int
-	customerID := state.export.CustomerID()
+	queryParams.Set("fields", "*navigable,attachment,security")
+	queryParams.Set("jql", jql(projectKeys))
+	queryParams.Set("maxResults", strconv.Itoa(issuesPageSize))
 	started := time.Now()
+	processPage := func(page *issuePage) error {
+		if err := i.processIssuesPage(state, page, customfields); err != nil {
+			return err
+		}
+		if onPage != nil {
+			return onPage(page)
+		}
+		return nil
+	}
+	// fetch the first page by itself so we know the total and can fix the query if needed
+	var first *issuePage
 	for {
-		queryParams.Set("startAt", strconv.Itoa(count))
-		var resp issueQueryResult
-		ts := time.Now()
-		r, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...)
-		if err := i.checkForRateLimit(state.logger, state.export, customerID, err, r.Headers); err != nil {
+		page, r, err := i.fetchIssuesPage(state, client, queryParams, 0)
+		if err != nil {
+			if r == nil {
+				return fmt.Errorf("error fetching issues: %w", err)
+			}
 			if issueError, ok := toIssueError(r.Body); ok {
 				invalidProjectIDs := issueError.getInvalidProjects()
 				if len(invalidProjectIDs) > 0 {
-					queryParams.Set("jql", issueSearchJQL(removeKeys(projectKeys, invalidProjectIDs), fromTime))
+					projectKeys = removeKeys(projectKeys, invalidProjectIDs)
 					sdk.LogInfo(state.logger, "found invalid projects, removing from query", "projects", invalidProjectIDs)
+					if len(projectKeys) == 0 {
+						return nil
+					}
+					queryParams.Set("jql", jql(projectKeys))
 					continue
 				}
 			}
 			return fmt.Errorf("error fetching issues: %w", err)
 		}
-		toprocess := make([]issueSource, 0)
-		for _, i := range resp.Issues {
-			if !state.issueIDManager.isProcessed(i.Key) {
-				state.issueIDManager.cache(i.Key, i.ID) // since we're coming in out of order, try and reduce ref fetches
-				state.issueIDManager.cache(i.ID, i.ID)  // do both since you can look it up by either
-				toprocess = append(toprocess, i)
-			}
+		first = page
+		break
+	}
+	if err := processPage(first); err != nil {
+		return err
+	}
+	// after the first page, go ahead and flush the data
+	state.pipe.Flush()
+	total := first.resp.Total
+	count := len(first.resp.Issues)
+	// jira may return less than we asked for so use what it gave us as the page size
+	pageSize := count
+	concurrency := state.issueConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	// fetch the remaining pages in batches of concurrent requests but process them in the order
+	// of the search so that the most recently updated issues are still sent first
+	for pageSize > 0 && count < total {
+		// the offsets are a guess from the size of the pages so far, any page which doesn't start where
+		// the previous one ended is thrown away and the next batch starts from the gap
+		offsets := make([]int, 0, concurrency)
+		for startAt := count; startAt < total && len(offsets) < concurrency; startAt += pageSize {
+			offsets = append(offsets, startAt)
 		}
-		// only process issues that haven't already been processed before (given recursion)
-		for _, i := range toprocess {
-			issue, comments, err := i.ToModel(customerID, state.integrationInstanceID, state.issueIDManager, state.sprintManager, state.userManager, customfields, state.authConfig.WebsiteURL, true)
-			if err != nil {
-				return err
+		pages := make([]*issuePage, len(offsets))
+		async := sdk.NewAsync(concurrency)
+		for n, startAt := range offsets {
+			n, startAt := n, startAt
+			async.Do(func() error {
+				page, _, err := i.fetchIssuesPage(state, client, queryParams, startAt)
+				if err != nil {
+					return fmt.Errorf("error fetching issues: %w", err)
+				}
+				pages[n] = page
+				return nil
+			})
+		}
+		if err := async.Wait(); err != nil {
+			return err
+		}
+		for _, page := range pages {
+			if page.startAt != count {
+				break
 			}
-			if err := state.pipe.Write(issue); err != nil {
+			if err := processPage(page); err != nil {
 				return err
 			}
-			for _, comment := range comments {
-				if err := state.pipe.Write(comment); err != nil {
-					return err
-				}
-				state.stats.incComment()
+			if len(page.resp.Issues) == 0 {
+				// the result set shrank while we were paging, nothing more to fetch
+				total = count
+				break
 			}
-			state.stats.incIssue()
-		}
-		if len(resp.Issues) > 0 {
-			sdk.LogDebug(state.logger, "fetched issues", "len", len(resp.Issues), "total", resp.Total, "count", count, "first", resp.Issues[0].Key, "last", resp.Issues[len(resp.Issues)-1].Key, "duration", time.Since(ts))
-		} else {
-			sdk.LogDebug(state.logger, "fetched issues", "len", len(resp.Issues), "total", resp.Total, "count", count, "duration", time.Since(ts))
-		}
-		// after the first page, go ahead and flush the data
-		if count == 0 {
-			state.pipe.Flush()
-		}
-		count += len(resp.Issues)
-		if count >= resp.Total {
-			break
+			count += len(page.resp.Issues)
 		}
 	}
-	sdk.LogInfo(state.logger, "export issues completed", "duration", time.Since(started), "count", count)
+	sdk.LogInfo(state.logger, "export issues completed", "duration", time.Since(started), "count", count, "concurrency", concurrency)
 	return nil
 }
 
@@ -432,16 +662,31 @@ type configIdentifier interface {
 	Logger() sdk.Logger
 }
 
-func (i *JiraIntegration) createAuthConfig(ci configIdentifier) (authConfig, error) {
-	return i.createAuthConfigFromConfig(ci.Logger(), ci, ci.Config())
+func (i *JiraIntegration) createAuthConfig(ci configIdentifier, middleware ...sdk.WithHTTPOption) (authConfig, error) {
+	return i.createAuthConfigFromConfig(ci.Logger(), ci, ci.Config(), middleware...)
 }
 
-func (i *JiraIntegration) createAuthConfigFromConfig(logger sdk.Logger, identifier sdk.Identifier, config sdk.Config) (authConfig, error) {
+// createAuthConfigFromConfig returns the auth config for the instance with the api it should use, any middleware passed
+// is added to every request including the one to detect the api
+func (i *JiraIntegration) createAuthConfigFromConfig(logger sdk.Logger, identifier sdk.Identifier, config sdk.Config, extra ...sdk.WithHTTPOption) (authConfig, error) {
 	auth, err := newAuth(logger, i.manager, identifier, i.httpmanager, config)
 	if err != nil {
 		return authConfig{}, err
 	}
-	return auth.Apply()
+	authConfig, err := auth.Apply()
+	if err != nil {
+		return authConfig, err
+	}
+	// every request made with the auth config is rate limited, and controls are told when we have to wait
+	control, _ := identifier.(sdk.Control)
+	middleware := append(authConfig.Middleware, withRateLimit(logger, i.rateLimiter(authConfig.APIURL), control))
+	middleware = append(middleware, extra...)
+	// don't leave any capacity since callers append to the middleware concurrently
+	authConfig.Middleware = middleware[:len(middleware):len(middleware)]
+	if err := i.detectAPI(logger, &authConfig); err != nil {
+		return authConfig, err
+	}
+	return authConfig, nil
 }
 
 func (i *JiraIntegration) newState(logger sdk.Logger, pipe sdk.Pipe, authConfig authConfig, config sdk.Config, historical bool, integrationInstanceID string) *state {
@@ -452,60 +697,118 @@ func (i *JiraIntegration) newState(logger sdk.Logger, pipe sdk.Pipe, authConfig
 		logger:                logger,
 		historical:            historical,
 		integrationInstanceID: integrationInstanceID,
+		issueConcurrency:      issueConcurrency(config),
+		issueFilter:           issueFilter(config),
+		restriction:           restrictedContent(config),
 	}
 }
 
+// configKeyLastExportTimestamp is only read to migrate to the per project watermarks
 const configKeyLastExportTimestamp = "last_export_ts"
 
 // Export is called to tell the integration to run an export
-func (i *JiraIntegration) Export(export sdk.Export) error {
+func (i *JiraIntegration) Export(export sdk.Export) (err error) {
 	logger := sdk.LogWith(export.Logger(), "job_id", export.JobID())
 	sdk.LogInfo(logger, "export started")
-	authConfig, err := i.createAuthConfig(export)
+	telemetry := newExportTelemetry()
+	exportStats := &stats{
+		started: telemetry.started,
+	}
+	historical := export.Historical()
+	defer func() {
+		summary := telemetry.summary(export.JobID(), historical, exportStats, err)
+		sdk.LogInfo(logger, "export telemetry", "requests", summary.Requests, "errors", summary.Errors, "retries", summary.Retries, "bytes", summary.BytesReceived, "rate_limit_pauses", summary.RateLimitPauses)
+		if serr := saveExportSummary(export.State(), summary); serr != nil {
+			sdk.LogError(logger, "error saving export summary", "err", serr)
+		}
+	}()
+	// record every request the export makes
+	authConfig, err := i.createAuthConfig(&telemetryExport{Export: export, telemetry: telemetry}, telemetry.middleware())
 	if err != nil {
 		return fmt.Errorf("error creating auth config: %w", err)
 	}
-	state := i.newState(logger, export.Pipe(), authConfig, export.Config(), export.Historical(), export.IntegrationInstanceID())
+	checkpoint, err := loadExportCheckpoint(export.State())
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		sdk.LogInfo(logger, "resuming an interrupted historical export", "started", checkpoint.Started)
+		historical = true
+	}
+	state := i.newState(logger, export.Pipe(), authConfig, export.Config(), historical, export.IntegrationInstanceID())
+	if err := validateIssueFilter(state.issueFilter); err != nil {
+		return err
+	}
+	if err := validateRestrictedContent(state.restriction); err != nil {
+		return err
+	}
 	state.manager = i.manager
 	state.export = export
-	state.stats = &stats{
-		started: time.Now(),
+	state.stats = exportStats
+	state.attachments = i.newAttachmentMirror(export, export.State(), authConfig, true)
+	state.comments = newExportedComments(export.State())
+	exportStarted := state.stats.started
+	if historical {
+		if checkpoint == nil {
+			checkpoint = newExportCheckpoint(export.State(), exportStarted)
+			if err := checkpoint.start(); err != nil {
+				return err
+			}
+		} else {
+			// use when the export was first started so that the next export will pick up changes made since then
+			exportStarted = checkpoint.Started
+		}
+		state.checkpoint = checkpoint
 	}
 	if err := i.installWebHookIfNecessary(logger, export.Config(), export.State(), state.authConfig, export.CustomerID(), export.IntegrationInstanceID()); err != nil {
 		return fmt.Errorf("error installing webhooks: %w", err)
 	}
-	var fromTime time.Time
-	var fromTimeStr string
-	if export.Historical() {
+	watermarks, err := loadProjectWatermarks(export.State())
+	if err != nil {
+		return err
+	}
+	if historical {
 		sdk.LogInfo(logger, "historical has been requested")
-	} else {
-		if _, err := export.State().Get(configKeyLastExportTimestamp, &fromTimeStr); err != nil {
-			return fmt.Errorf("error getting last export time from state: %w", err)
-		}
-		if fromTimeStr != "" {
-			fromTime, _ = time.Parse(time.RFC3339Nano, fromTimeStr)
-			sdk.LogInfo(logger, "will start from a specific timestamp", "time", fromTime)
-		} else {
-			sdk.LogInfo(logger, "no specific timestamp found, will start from now")
-		}
 	}
+	endCustomFields := telemetry.phase(phaseCustomFields)
 	customfields, err := i.fetchCustomFields(logger, state.export, export.CustomerID(), state.authConfig)
+	endCustomFields()
 	if err != nil {
 		return fmt.Errorf("error fetching custom fields: %w", err)
 	}
+	fieldIDs := i.findCustomFieldIDs(logger, export.CustomerID(), export.IntegrationInstanceID(), state.authConfig, customfields)
+	// refresh the fields the webhooks use
+	if err := cacheCustomFields(export.State(), customfields); err != nil {
+		return err
+	}
+	if err := cacheCustomFieldIDs(export.State(), fieldIDs); err != nil {
+		return err
+	}
+	if err := applyCustomFieldMappings(logger, state.config, customfields); err != nil {
+		return err
+	}
 	state.sprintManager = newSprintManager(export.CustomerID(), state.pipe, state.stats, export.IntegrationInstanceID(), state.authConfig.SupportsAgileAPI)
 	state.userManager = newUserManager(export.CustomerID(), state.authConfig.WebsiteURL, state.pipe, state.stats, export.IntegrationInstanceID())
-	state.issueIDManager = newIssueIDManager(logger, i, state.export, state.pipe, state.sprintManager, state.userManager, customfields, state.authConfig, state.stats)
-	if err := i.processWorkConfig(logger, state.config, state.pipe, export.State(), export.CustomerID(), export.IntegrationInstanceID(), export.Historical()); err != nil {
+	state.issueIDManager = newIssueIDManager(logger, i, state.export, state.pipe, state.sprintManager, state.userManager, customfields, state.authConfig, state.issueFilter, state.restriction, state.stats)
+	state.issueIDManager.fieldIDs = fieldIDs
+	state.issueIDManager.attachments = state.attachments
+	endWorkConfig := telemetry.phase(phaseWorkConfig)
+	err = i.processWorkConfig(logger, state.config, state.pipe, export.State(), export.CustomerID(), export.IntegrationInstanceID(), historical)
+	endWorkConfig()
+	if err != nil {
 		return err
 	}
-	projectKeys, err := i.fetchProjectsPaginated(state)
+	endProjects := telemetry.phase(phaseProjects)
+	projectKeys, newProjectKeys, err := i.fetchProjectsPaginated(state)
+	endProjects()
 	if err != nil {
 		return fmt.Errorf("error fetching projects: %w", err)
 	}
 	if len(projectKeys) == 0 {
 		sdk.LogInfo(logger, "no projects found to export")
 	} else {
+		// the boards are fetched in the background while we fetch the issues
+		endBoards := telemetry.phase(phaseBoards)
 		if err := state.sprintManager.init(state); err != nil {
 			return fmt.Errorf("error in sprintmanager: %w", err)
 		}
@@ -515,15 +818,46 @@ func (i *JiraIntegration) Export(export sdk.Export) error {
 		if err := i.fetchTypes(state); err != nil {
 			return fmt.Errorf("error fetching types: %w", err)
 		}
-		if err := i.fetchIssuesPaginated(state, fromTime, customfields, projectKeys); err != nil {
+		endIssues := telemetry.phase(phaseIssues)
+		if state.checkpoint != nil {
+			err = i.fetchIssuesResumable(state, state.checkpoint, customfields, projectKeys)
+		} else {
+			err = i.fetchIssuesIncremental(state, watermarks, exportStarted, customfields, projectKeys, newProjectKeys)
+		}
+		endIssues()
+		// save the comments of the issues we exported even if we didn't get them all
+		if serr := state.comments.save(); serr != nil && err == nil {
+			err = serr
+		}
+		if err != nil {
+			// wait for the boards so the checkpoint has them before we stop
+			state.sprintManager.blockForFetchBoards(logger)
+			endBoards()
 			return fmt.Errorf("error fetching issues: %w", err)
 		}
-		if err := state.sprintManager.blockForFetchBoards(logger); err != nil {
+		err = state.sprintManager.blockForFetchBoards(logger)
+		endBoards()
+		if err != nil {
 			return fmt.Errorf("error waiting for fetched sprints: %w", err)
 		}
+		// always do this for historical so that we have the issues to compare with next time
+		endReconcile := telemetry.phase(phaseReconcile)
+		err = i.reconcileDeletedIssues(state, projectKeys, historical)
+		endReconcile()
+		if err != nil {
+			return fmt.Errorf("error reconciling deleted issues: %w", err)
+		}
 	}
-	if err := export.State().Set(configKeyLastExportTimestamp, state.stats.started.Format(time.RFC3339Nano)); err != nil {
-		return fmt.Errorf("error writing last export date to state: %w", err)
+	if err := watermarks.set(projectKeys, exportStarted); err != nil {
+		return err
+	}
+	if err := watermarks.removeLegacy(projectKeys); err != nil {
+		return err
+	}
+	if state.checkpoint != nil {
+		if err := state.checkpoint.clear(); err != nil {
+			return err
+		}
 	}
 	state.stats.dump(logger)
 	return nil
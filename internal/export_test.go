@@ -3,6 +3,7 @@ package internal
 import (
 	"testing"
 
+	"github.com/pinpt/agent/v4/sdk"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,3 +25,40 @@ func TestGetInvalidProjects(t *testing.T) {
 	assert.EqualValues("13085", v[7])
 	assert.EqualValues("13116", v[8])
 }
+
+func TestExportIssuesWithShortPages(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.pageSize = 10
+	jira.addProject("10000", "ABC", 45)
+	// jira can return fewer issues than the page size, which would leave a gap if we guessed the next offset
+	jira.shortPages[10] = 4
+	jira.shortPages[24] = 7
+
+	integration := newMockIntegration()
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyIssueConcurrency: "3"})
+	assert.NoError(integration.Export(export))
+
+	issues := make(map[string]int)
+	for _, object := range export.pipe.written {
+		if issue, ok := object.(*sdk.WorkIssue); ok {
+			issues[issue.RefID]++
+		}
+	}
+	assert.Len(issues, 45)
+	for refID, count := range issues {
+		assert.Equal(1, count, "issue %s was exported more than once", refID)
+	}
+}
+
+func TestIssueConcurrency(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(defaultIssueConcurrency, issueConcurrency(sdk.NewConfig(nil)))
+	assert.Equal(defaultIssueConcurrency, issueConcurrency(sdk.NewConfig(map[string]interface{}{configKeyIssueConcurrency: ""})))
+	assert.Equal(10, issueConcurrency(sdk.NewConfig(map[string]interface{}{configKeyIssueConcurrency: "10"})))
+	assert.Equal(8, issueConcurrency(sdk.NewConfig(map[string]interface{}{configKeyIssueConcurrency: float64(8)})))
+	assert.Equal(1, issueConcurrency(sdk.NewConfig(map[string]interface{}{configKeyIssueConcurrency: "-3"})))
+	assert.Equal(maxIssueConcurrency, issueConcurrency(sdk.NewConfig(map[string]interface{}{configKeyIssueConcurrency: 500})))
+}
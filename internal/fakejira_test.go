@@ -0,0 +1,743 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+// mockHTTPManager creates clients which talk to a real http server such as a fakeJira
+type mockHTTPManager struct{}
+
+var _ sdk.HTTPClientManager = (*mockHTTPManager)(nil)
+
+func (m *mockHTTPManager) New(url string, headers map[string]string) sdk.HTTPClient {
+	return &mockHTTPClient{url: url, headers: headers}
+}
+
+type mockHTTPClient struct {
+	url     string
+	headers map[string]string
+}
+
+var _ sdk.HTTPClient = (*mockHTTPClient)(nil)
+
+// do works like the agent's http client, letting the options retry the request and retrying rate limited responses
+func (c *mockHTTPClient) do(method string, data io.Reader, out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	var buf []byte
+	if data != nil {
+		var err error
+		if buf, err = ioutil.ReadAll(data); err != nil {
+			return nil, err
+		}
+	}
+	deadline := time.Now().Add(time.Minute)
+	for {
+		req, err := http.NewRequest(method, c.url, bytes.NewReader(buf))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range c.headers {
+			req.Header.Set(k, v)
+		}
+		opts := &sdk.HTTPOptions{Request: req, Deadline: deadline, Transport: http.DefaultTransport}
+		for _, o := range options {
+			if err := o(opts); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := (&http.Client{Transport: opts.Transport}).Do(opts.Request)
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res := &sdk.HTTPResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       body,
+		}
+		opts.Response = res
+		for _, o := range options {
+			if err := o(opts); err != nil {
+				return nil, err
+			}
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			opts.ShouldRetry = true
+			opts.RetryAfter = 30 * time.Second
+			if secs, _ := strconv.Atoi(resp.Header.Get("Retry-After")); secs > 0 {
+				opts.RetryAfter = time.Duration(secs) * time.Second
+			}
+		}
+		if opts.ShouldRetry || resp.StatusCode == http.StatusServiceUnavailable {
+			if time.Now().Before(opts.Deadline) {
+				time.Sleep(opts.RetryAfter)
+			}
+			if time.Now().Before(opts.Deadline) {
+				continue
+			}
+			return nil, sdk.ErrTimedOut
+		}
+		if resp.StatusCode > 299 {
+			return res, &sdk.HTTPError{StatusCode: resp.StatusCode, Body: bytes.NewReader(body)}
+		}
+		if out != nil && len(body) > 0 {
+			return res, json.Unmarshal(body, out)
+		}
+		return res, nil
+	}
+}
+
+func (c *mockHTTPClient) Get(out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return c.do(http.MethodGet, nil, out, options...)
+}
+
+func (c *mockHTTPClient) Post(data io.Reader, out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return c.do(http.MethodPost, data, out, options...)
+}
+
+func (c *mockHTTPClient) Put(data io.Reader, out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return c.do(http.MethodPut, data, out, options...)
+}
+
+func (c *mockHTTPClient) Patch(data io.Reader, out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return c.do(http.MethodPatch, data, out, options...)
+}
+
+func (c *mockHTTPClient) Delete(out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return c.do(http.MethodDelete, nil, out, options...)
+}
+
+// mockWebHookManager reports that the current version of the webhook is already installed
+type mockWebHookManager struct {
+	sdk.WebHookManager
+}
+
+func (m *mockWebHookManager) Exists(customerID string, integrationInstanceID string, refType string, refID string, scope sdk.WebHookScope) bool {
+	return true
+}
+
+func (m *mockWebHookManager) HookURL(customerID string, integrationInstanceID string, refType string, refID string, scope sdk.WebHookScope) (string, error) {
+	return "https://webhook.example.com/hook?integration=jira&version=" + webhookVersion, nil
+}
+
+type mockManager struct {
+	sdk.Manager
+	httpmanager sdk.HTTPClientManager
+}
+
+func (m *mockManager) HTTPManager() sdk.HTTPClientManager       { return m.httpmanager }
+func (m *mockManager) WebHookManager() sdk.WebHookManager       { return &mockWebHookManager{} }
+func (m *mockManager) GraphQLManager() sdk.GraphQLClientManager { return nil }
+
+func newMockIntegration() *JiraIntegration {
+	httpmanager := &mockHTTPManager{}
+	return &JiraIntegration{
+		manager:     &mockManager{httpmanager: httpmanager},
+		httpmanager: httpmanager,
+	}
+}
+
+// mockState is an in memory sdk.State
+type mockState struct {
+	data map[string][]byte
+	// flushed is the data as of the last flush
+	flushed map[string][]byte
+	mu      sync.Mutex
+}
+
+var _ sdk.State = (*mockState)(nil)
+
+func newMockState() *mockState {
+	return &mockState{data: make(map[string][]byte)}
+}
+
+func (s *mockState) Set(key string, value interface{}) error {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.data[key] = buf
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *mockState) SetWithExpires(key string, value interface{}, expiry time.Duration) error {
+	return s.Set(key, value)
+}
+
+func (s *mockState) Get(key string, out interface{}) (bool, error) {
+	s.mu.Lock()
+	buf, ok := s.data[key]
+	s.mu.Unlock()
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(buf, out)
+}
+
+func (s *mockState) Exists(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[key]
+	return ok
+}
+
+func (s *mockState) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.data, key)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *mockState) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushed = make(map[string][]byte)
+	for key, buf := range s.data {
+		s.flushed[key] = buf
+	}
+	return nil
+}
+
+// isFlushed returns true if the value of key was flushed
+func (s *mockState) isFlushed(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flushed, ok := s.flushed[key]
+	return ok && string(flushed) == string(s.data[key])
+}
+
+// mockPipe is like sdktest.MockPipe but safe to write to from multiple goroutines
+type mockPipe struct {
+	written []sdk.Model
+	mu      sync.Mutex
+}
+
+var _ sdk.Pipe = (*mockPipe)(nil)
+
+func (p *mockPipe) Write(object sdk.Model) error {
+	p.mu.Lock()
+	p.written = append(p.written, object)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *mockPipe) Flush() error { return nil }
+func (p *mockPipe) Close() error { return nil }
+
+type mockExport struct {
+	config     sdk.Config
+	state      sdk.State
+	pipe       *mockPipe
+	historical bool
+
+	mu      sync.Mutex
+	paused  int
+	resumed int
+}
+
+var _ sdk.Export = (*mockExport)(nil)
+
+func newMockExport(url string, state sdk.State, historical bool) *mockExport {
+	config := sdk.Config{}
+	if err := config.Parse(makeMockAuth(url)); err != nil {
+		panic(err)
+	}
+	return &mockExport{
+		config:     config,
+		state:      state,
+		pipe:       &mockPipe{},
+		historical: historical,
+	}
+}
+
+func (e *mockExport) Config() sdk.Config            { return e.config }
+func (e *mockExport) State() sdk.State              { return e.state }
+func (e *mockExport) Stats() sdk.Stats              { return sdk.NewStats() }
+func (e *mockExport) JobID() string                 { return "job" }
+func (e *mockExport) Pipe() sdk.Pipe                { return e.pipe }
+func (e *mockExport) Historical() bool              { return e.historical }
+func (e *mockExport) Logger() sdk.Logger            { return sdk.NewNoOpTestLogger() }
+func (e *mockExport) CustomerID() string            { return "1234" }
+func (e *mockExport) IntegrationInstanceID() string { return "1" }
+func (e *mockExport) RefType() string               { return "jira" }
+
+func (e *mockExport) Paused(resetAt time.Time) error {
+	e.mu.Lock()
+	e.paused++
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *mockExport) Resumed() error {
+	e.mu.Lock()
+	e.resumed++
+	e.mu.Unlock()
+	return nil
+}
+
+type mockValidate struct {
+	config sdk.Config
+	state  sdk.State
+}
+
+var _ sdk.Validate = (*mockValidate)(nil)
+
+func newMockValidate(url string, kv map[string]interface{}) *mockValidate {
+	config := sdk.Config{}
+	if err := config.Parse(makeMockAuth(url)); err != nil {
+		panic(err)
+	}
+	config.Merge(kv)
+	return &mockValidate{config: config, state: newMockState()}
+}
+
+func (v *mockValidate) Config() sdk.Config             { return v.config }
+func (v *mockValidate) State() sdk.State               { return v.state }
+func (v *mockValidate) Logger() sdk.Logger             { return sdk.NewNoOpTestLogger() }
+func (v *mockValidate) Paused(resetAt time.Time) error { return nil }
+func (v *mockValidate) Resumed() error                 { return nil }
+func (v *mockValidate) CustomerID() string             { return "1234" }
+func (v *mockValidate) IntegrationInstanceID() string  { return "1" }
+func (v *mockValidate) RefType() string                { return "jira" }
+
+// fakeJira is a http server which implements enough of the Jira APIs to run an export
+type fakeJira struct {
+	server   *httptest.Server
+	projects []project
+	issues   map[string][]issueSource // by project id
+	boards   []boardSource
+	pageSize int
+	// changelogs are the full changelog histories by issue id
+	changelogs map[string][]changeLogHistory
+	// comments are all of the comments by issue id
+	comments map[string][]comment
+
+	mu sync.Mutex
+	// jqls are the queries of each search request
+	jqls []string
+	// failSearch, if set, will fail the search request when it returns true
+	failSearch func(jql string, startAt int) bool
+	// shortPages are the number of issues to return for the search pages starting at these offsets
+	shortPages map[int]int
+	// failBoards will fail fetching the configuration for these boards
+	failBoards map[int]bool
+	// excluded are the ids of the issues that a search containing filter won't return
+	filter   string
+	excluded map[string]bool
+	// invalidJQL will fail any search containing it with a jql error
+	invalidJQL string
+	// sprints are the sprint ids by board id
+	sprints map[int][]int
+	// estimationFields are the ids of the fields the boards estimate with by board id
+	estimationFields map[int]string
+	// headers are added to every response
+	headers map[string]string
+	// throttled is the number of requests to rate limit before handling them again
+	throttled int
+	// deploymentType is what the server info reports, only cloud has v3 of the api
+	deploymentType string
+	// attachments are the contents of the attachments by id
+	attachments map[string][]byte
+	// downloads are the ids of the attachments which have been downloaded
+	downloads []string
+}
+
+// testDeployments are the deployment types tests which should work with both versions of the api run against
+var testDeployments = []string{deploymentCloud, deploymentDataCenter}
+
+func newFakeJira() *fakeJira {
+	return newFakeJiraDeployment(deploymentCloud)
+}
+
+// newFakeJiraDeployment returns a fake jira which only answers the api version of the deployment type
+func newFakeJiraDeployment(deploymentType string) *fakeJira {
+	f := &fakeJira{
+		deploymentType:   deploymentType,
+		issues:           make(map[string][]issueSource),
+		changelogs:       make(map[string][]changeLogHistory),
+		comments:         make(map[string][]comment),
+		pageSize:         issuesPageSize,
+		failBoards:       make(map[int]bool),
+		shortPages:       make(map[int]int),
+		excluded:         make(map[string]bool),
+		sprints:          make(map[int][]int),
+		estimationFields: make(map[int]string),
+		headers:          make(map[string]string),
+		attachments:      make(map[string][]byte),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+func (f *fakeJira) URL() string { return f.server.URL }
+func (f *fakeJira) Close()      { f.server.Close() }
+
+func (f *fakeJira) apiPath() string {
+	if f.deploymentType == deploymentCloud {
+		return apiPathV3
+	}
+	return apiPathV2
+}
+
+// content returns text the way the deployment sends descriptions and comments, ADF for cloud and wiki markup otherwise
+func (f *fakeJira) content(text string) json.RawMessage {
+	if f.deploymentType == deploymentCloud {
+		buf, _ := json.Marshal(contentValue(authConfig{APIPath: apiPathV3}, text))
+		return buf
+	}
+	buf, _ := json.Marshal(text)
+	return buf
+}
+
+// addProject adds a project with count issues which have keys starting at 1
+func (f *fakeJira) addProject(id string, key string, count int) {
+	f.projects = append(f.projects, project{ID: id, Key: key, Name: key, ProjectTypeKey: "software"})
+	for n := 1; n <= count; n++ {
+		f.issues[id] = append(f.issues[id], issueSource{
+			ID:  fmt.Sprintf("%s%04d", id, n),
+			Key: fmt.Sprintf("%s-%d", key, n),
+			Fields: map[string]interface{}{
+				"project": map[string]interface{}{"id": id, "key": key},
+				"summary": fmt.Sprintf("issue %d", n),
+				"created": "2020-10-01T10:00:00.000+0000",
+				"updated": "2020-10-02T10:00:00.000+0000",
+			},
+		})
+	}
+}
+
+// setProjectType changes the type of a project which was added as a software project
+func (f *fakeJira) setProjectType(id string, typeKey string) {
+	for n := range f.projects {
+		if f.projects[n].ID == id {
+			f.projects[n].ProjectTypeKey = typeKey
+		}
+	}
+}
+
+// setComments sets the comments for the issue with only the first embedded of them returned with the issue
+func (f *fakeJira) setComments(issue *issueSource, comments []comment, embedded int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.comments[issue.ID] = comments
+	if embedded > len(comments) {
+		embedded = len(comments)
+	}
+	issue.Fields["comment"] = commentQueryResult{
+		MaxResults: embedded,
+		Total:      len(comments),
+		Comments:   comments[:embedded],
+	}
+}
+
+// addAttachment adds an attachment to the issue, with a content url on the site like jira has
+func (f *fakeJira) addAttachment(issue *issueSource, id string, mimeType string, content []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attachments[id] = content
+	attachments, _ := issue.Fields["attachment"].([]attachment)
+	a := attachment{ID: id, Filename: "file" + id, MimeType: mimeType, Size: len(content), Created: "2020-10-01T10:00:00.000+0000"}
+	a.Content = f.URL() + "/secure/attachment/" + id + "/" + a.Filename
+	issue.Fields["attachment"] = append(attachments, a)
+}
+
+func (f *fakeJira) handleAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	f.mu.Lock()
+	content, ok := f.attachments[id]
+	if ok {
+		f.downloads = append(f.downloads, id)
+	}
+	f.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Write(content)
+}
+
+func (f *fakeJira) addBoard(id int, projectID int, projectKey string) {
+	board := boardSource{ID: id, Name: fmt.Sprintf("Board %d", id), Type: "scrum"}
+	board.Location.ID = projectID
+	board.Location.ProjectKey = projectKey
+	f.boards = append(f.boards, board)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+var (
+	boardConfigurationPathRE = regexp.MustCompile(`^/rest/agile/1.0/board/(\d+)/configuration$`)
+	boardSprintsPathRE       = regexp.MustCompile(`^/rest/agile/1.0/board/(\d+)/sprint$`)
+	issuePathRE              = regexp.MustCompile(`^/rest/api/3/issue/(\w+)$`)
+	issueChangelogPathRE     = regexp.MustCompile(`^/rest/api/3/issue/(\w+)/changelog$`)
+	issueCommentsPathRE      = regexp.MustCompile(`^/rest/api/3/issue/(\w+)/comment$`)
+	attachmentContentPathRE  = regexp.MustCompile(`^/rest/api/3/attachment/content/(\d+)$`)
+	secureAttachmentPathRE   = regexp.MustCompile(`^/secure/attachment/(\d+)/`)
+)
+
+func (f *fakeJira) handle(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	for k, v := range f.headers {
+		w.Header().Set(k, v)
+	}
+	f.mu.Lock()
+	throttle := f.throttled > 0
+	if throttle {
+		f.throttled--
+	}
+	f.mu.Unlock()
+	if throttle {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+		return
+	}
+	if path == serverInfoPath {
+		writeJSON(w, serverInfo{Version: "8.13.0", VersionNumbers: []int{8, 13, 0}, DeploymentType: f.deploymentType})
+		return
+	}
+	// only answer the version of the api the deployment has, the handlers below are for v3 paths
+	if strings.HasPrefix(path, "/rest/api/") {
+		if !strings.HasPrefix(path, f.apiPath()+"/") {
+			http.NotFound(w, r)
+			return
+		}
+		path = apiPathV3 + strings.TrimPrefix(path, f.apiPath())
+	}
+	switch {
+	case path == "/rest/api/3/search":
+		f.handleSearch(w, r)
+	case path == "/rest/api/3/project/search":
+		projects := make([]project, 0)
+		for _, p := range f.projects {
+			if typeKey := r.URL.Query().Get("typeKey"); typeKey == "" || sliceContains(strings.Split(typeKey, ","), p.ProjectTypeKey) {
+				projects = append(projects, p)
+			}
+		}
+		writeJSON(w, map[string]interface{}{"total": len(projects), "values": projects})
+	case path == "/rest/api/3/issue/createmeta":
+		writeJSON(w, map[string]interface{}{"projects": []interface{}{}})
+	case path == "/rest/api/3/field", path == "/rest/api/3/status", path == "/rest/api/3/resolution",
+		path == "/rest/api/3/priority", path == "/rest/api/3/issuetype", strings.HasSuffix(path, "/statuses"):
+		writeJSON(w, []interface{}{})
+	case issuePathRE.MatchString(path):
+		f.handleIssue(w, r, issuePathRE.FindStringSubmatch(path)[1])
+	case issueChangelogPathRE.MatchString(path):
+		f.handleChangelog(w, r, issueChangelogPathRE.FindStringSubmatch(path)[1])
+	case issueCommentsPathRE.MatchString(path):
+		f.handleComments(w, r, issueCommentsPathRE.FindStringSubmatch(path)[1])
+	case attachmentContentPathRE.MatchString(path):
+		f.handleAttachment(w, r, attachmentContentPathRE.FindStringSubmatch(path)[1])
+	case secureAttachmentPathRE.MatchString(path):
+		f.handleAttachment(w, r, secureAttachmentPathRE.FindStringSubmatch(path)[1])
+	case path == "/rest/agile/1.0/board":
+		boards := make([]boardSource, 0)
+		for _, board := range f.boards {
+			if key := r.URL.Query().Get("projectKeyOrId"); key == "" || key == board.Location.ProjectKey {
+				boards = append(boards, board)
+			}
+		}
+		total := len(boards)
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		if startAt > total {
+			startAt = total
+		}
+		boards = boards[startAt:]
+		if max, _ := strconv.Atoi(r.URL.Query().Get("maxResults")); max > 0 && len(boards) > max {
+			boards = boards[:max]
+		}
+		writeJSON(w, map[string]interface{}{"total": total, "isLast": startAt+len(boards) == total, "values": boards})
+	case boardConfigurationPathRE.MatchString(path):
+		id, _ := strconv.Atoi(boardConfigurationPathRE.FindStringSubmatch(path)[1])
+		f.mu.Lock()
+		fail := f.failBoards[id]
+		f.mu.Unlock()
+		if fail {
+			http.Error(w, "board failed", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"columnConfig": map[string]interface{}{"columns": []interface{}{}},
+			"estimation":   map[string]interface{}{"type": "field", "field": map[string]interface{}{"fieldId": f.estimationFields[id]}},
+		})
+	case boardSprintsPathRE.MatchString(path):
+		id, _ := strconv.Atoi(boardSprintsPathRE.FindStringSubmatch(path)[1])
+		values := make([]interface{}, 0)
+		for _, sprintID := range f.sprints[id] {
+			values = append(values, map[string]interface{}{"id": sprintID, "state": "closed"})
+		}
+		writeJSON(w, map[string]interface{}{"isLast": true, "values": values})
+	case strings.HasSuffix(path, "/sprint"):
+		writeJSON(w, map[string]interface{}{"isLast": true, "values": []interface{}{}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+var (
+	jqlProjectsRE = regexp.MustCompile(`project in \(([^)]*)\)`)
+	jqlAfterKeyRE = regexp.MustCompile(`key > "([^"]+)"`)
+	jqlIDRE       = regexp.MustCompile(`id = (\d+)`)
+)
+
+func issueKeyNumber(key string) int {
+	n, _ := strconv.Atoi(key[strings.LastIndex(key, "-")+1:])
+	return n
+}
+
+func (f *fakeJira) handleSearch(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	qs := r.URL.Query()
+	jql := qs.Get("jql")
+	startAt, _ := strconv.Atoi(qs.Get("startAt"))
+	f.jqls = append(f.jqls, jql)
+	fail := f.failSearch != nil && f.failSearch(jql, startAt)
+	pageSize := f.pageSize
+	if n, ok := f.shortPages[startAt]; ok {
+		pageSize = n
+	}
+	f.mu.Unlock()
+	if fail {
+		http.Error(w, "search failed", http.StatusInternalServerError)
+		return
+	}
+	if f.invalidJQL != "" && strings.Contains(jql, f.invalidJQL) {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]interface{}{"errorMessages": []string{"Error in the JQL Query: " + f.invalidJQL}})
+		return
+	}
+	var afterKey string
+	if m := jqlAfterKeyRE.FindStringSubmatch(jql); m != nil {
+		afterKey = m[1]
+	}
+	var issueID string
+	if m := jqlIDRE.FindStringSubmatch(jql); m != nil {
+		issueID = m[1]
+	}
+	filtered := f.filter != "" && strings.Contains(jql, f.filter)
+	matches := make([]issueSource, 0)
+	for projectID, issues := range f.issues {
+		if m := jqlProjectsRE.FindStringSubmatch(jql); m != nil && !sliceContains(strings.Split(m[1], ","), projectID) {
+			continue
+		}
+		for _, issue := range issues {
+			if afterKey != "" && issueKeyNumber(issue.Key) <= issueKeyNumber(afterKey) {
+				continue
+			}
+			if issueID != "" && issue.ID != issueID {
+				continue
+			}
+			if filtered && f.excluded[issue.ID] {
+				continue
+			}
+			matches = append(matches, issue)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := issueKeyNumber(matches[i].Key), issueKeyNumber(matches[j].Key)
+		if a == b {
+			return matches[i].Key < matches[j].Key
+		}
+		return a < b
+	})
+	end := startAt + pageSize
+	if end > len(matches) {
+		end = len(matches)
+	}
+	page := make([]issueSource, 0)
+	if startAt < end {
+		page = matches[startAt:end]
+	}
+	writeJSON(w, map[string]interface{}{"total": len(matches), "issues": page})
+}
+
+func (f *fakeJira) handleIssue(w http.ResponseWriter, r *http.Request, issueID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, issues := range f.issues {
+		for _, issue := range issues {
+			if issue.ID == issueID {
+				writeJSON(w, issue)
+				return
+			}
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (f *fakeJira) handleChangelog(w http.ResponseWriter, r *http.Request, issueID string) {
+	histories, ok := f.changelogs[issueID]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if maxResults <= 0 || maxResults > 100 {
+		maxResults = 100
+	}
+	end := startAt + maxResults
+	if end > len(histories) {
+		end = len(histories)
+	}
+	values := make([]changeLogHistory, 0)
+	if startAt < end {
+		values = histories[startAt:end]
+	}
+	writeJSON(w, changeLogQueryResult{
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		Total:      len(histories),
+		IsLast:     end >= len(histories),
+		Values:     values,
+	})
+}
+
+func (f *fakeJira) handleComments(w http.ResponseWriter, r *http.Request, issueID string) {
+	f.mu.Lock()
+	comments, ok := f.comments[issueID]
+	f.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if maxResults <= 0 || maxResults > 100 {
+		maxResults = 100
+	}
+	end := startAt + maxResults
+	if end > len(comments) {
+		end = len(comments)
+	}
+	values := make([]comment, 0)
+	if startAt < end {
+		values = comments[startAt:end]
+	}
+	writeJSON(w, commentQueryResult{
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		Total:      len(comments),
+		Comments:   values,
+	})
+}
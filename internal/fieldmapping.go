@@ -0,0 +1,474 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+// the issue attributes a custom field can be mapped to
+const (
+	mappingTargetStoryPoints      = "story_points"
+	mappingTargetEpicName         = "epic_name"
+	mappingTargetPlannedStartDate = "planned_start_date"
+	mappingTargetPlannedEndDate   = "planned_end_date"
+	mappingTargetDueDate          = "due_date"
+	mappingTargetAssignee         = "assignee_ref_id"
+	// mappingTargetTags adds the values to the issue's tags with a prefix, which is the field name and a colon unless
+	// configured, so they can be used as key/value pairs since issues don't have a collection for custom fields
+	mappingTargetTags = "tags"
+)
+
+var mappingTargets = []string{
+	mappingTargetStoryPoints,
+	mappingTargetEpicName,
+	mappingTargetPlannedStartDate,
+	mappingTargetPlannedEndDate,
+	mappingTargetDueDate,
+	mappingTargetAssignee,
+	mappingTargetTags,
+}
+
+// the types of custom field values, which are the types jira uses in the field schemas
+const (
+	fieldTypeString   = "string"
+	fieldTypeNumber   = "number"
+	fieldTypeDate     = "date"
+	fieldTypeDatetime = "datetime"
+	fieldTypeOption   = "option"
+	fieldTypeUser     = "user"
+	fieldTypeArray    = "array"
+)
+
+var fieldTypes = []string{
+	fieldTypeString,
+	fieldTypeNumber,
+	fieldTypeDate,
+	fieldTypeDatetime,
+	fieldTypeOption,
+	fieldTypeUser,
+	fieldTypeArray,
+}
+
+// errCustomFieldValue is returned for a value which can't be converted to the type of the field or its target
+var errCustomFieldValue = errors.New("invalid custom field value")
+
+// customFieldMapping is where an instance has configured the value of a custom field to go on an issue
+// easyjson:skip
+type customFieldMapping struct {
+	// Field is the id or the name of the custom field
+	Field string `json:"field"`
+	// Target is the issue attribute the value goes to
+	Target string `json:"target"`
+	// Type and Items override the types from the field's schema
+	Type  string `json:"type,omitempty"`
+	Items string `json:"items,omitempty"`
+	// Prefix is put in front of each value for the tags target
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// customFieldMappings returns the custom field mappings configured for an instance, which can either be a json string or
+// an array
+func customFieldMappings(config sdk.Config) ([]customFieldMapping, error) {
+	found, val := config.Get(configKeyCustomFieldMappings)
+	if !found || val == nil {
+		return nil, nil
+	}
+	var buf []byte
+	if s, ok := val.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return nil, nil
+		}
+		buf = []byte(s)
+	} else {
+		var err error
+		if buf, err = json.Marshal(val); err != nil {
+			return nil, fmt.Errorf("error encoding %s: %w", configKeyCustomFieldMappings, err)
+		}
+	}
+	var mappings []customFieldMapping
+	if err := json.Unmarshal(buf, &mappings); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", configKeyCustomFieldMappings, err)
+	}
+	for _, m := range mappings {
+		if strings.TrimSpace(m.Field) == "" {
+			return nil, fmt.Errorf("custom field mapping is missing the field")
+		}
+		if !sliceContains(mappingTargets, m.Target) {
+			return nil, fmt.Errorf("custom field mapping for %s has an unknown target: %s", m.Field, m.Target)
+		}
+		if m.Type != "" && !sliceContains(fieldTypes, m.Type) {
+			return nil, fmt.Errorf("custom field mapping for %s has an unknown type: %s", m.Field, m.Type)
+		}
+		if m.Items != "" && !sliceContains(fieldTypes, m.Items) {
+			return nil, fmt.Errorf("custom field mapping for %s has an unknown items type: %s", m.Field, m.Items)
+		}
+	}
+	return mappings, nil
+}
+
+// findCustomField returns the id of the custom field with the id or name, which must be unique
+func findCustomField(fields map[string]customField, idOrName string) (string, error) {
+	idOrName = strings.TrimSpace(idOrName)
+	if _, ok := fields[idOrName]; ok {
+		return idOrName, nil
+	}
+	ids := make([]string, 0)
+	for id, field := range fields {
+		if strings.EqualFold(field.Name, idOrName) {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no custom field named %s", idOrName)
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("more than one custom field is named %s, use one of the ids instead: %s", idOrName, strings.Join(ids, ", "))
+}
+
+// applyCustomFieldMappings sets the mapping on each of the fields the instance has configured. a mapping for a field that
+// can't be found is skipped since the field may have been deleted, but an invalid configuration is an error
+func applyCustomFieldMappings(logger sdk.Logger, config sdk.Config, fields map[string]customField) error {
+	mappings, err := customFieldMappings(config)
+	if err != nil {
+		return err
+	}
+	for n := range mappings {
+		mapping := &mappings[n]
+		id, err := findCustomField(fields, mapping.Field)
+		if err != nil {
+			sdk.LogWarn(logger, "skipping custom field mapping", "field", mapping.Field, "err", err)
+			continue
+		}
+		field := fields[id]
+		field.Mapping = mapping
+		fields[id] = field
+	}
+	return nil
+}
+
+// valueTypes returns the type of the field's value and the type of the values in it for an array
+func (f customField) valueTypes() (string, string) {
+	typ, items := f.Type, f.Items
+	if f.Mapping != nil {
+		if f.Mapping.Type != "" {
+			typ = f.Mapping.Type
+		}
+		if f.Mapping.Items != "" {
+			items = f.Mapping.Items
+		}
+	}
+	return typ, items
+}
+
+// tags returns the values of the field as tags
+func (f customField) tags(values []string) []string {
+	prefix := f.Name + ":"
+	if f.Mapping != nil && f.Mapping.Prefix != nil {
+		prefix = *f.Mapping.Prefix
+	}
+	tags := make([]string, 0, len(values))
+	for _, v := range values {
+		tags = append(tags, prefix+v)
+	}
+	return tags
+}
+
+// parseCustomFieldDate supports both date and datetime fields
+func parseCustomFieldDate(val string) (time.Time, error) {
+	if d, err := parsePlannedDate(val); err == nil {
+		return d, nil
+	}
+	return parseTime(val)
+}
+
+// coerceCustomFieldValue converts the value of a custom field to strings, with one for each of the values in an array.
+// the users in it are emitted
+func coerceCustomFieldValue(typ string, items string, value interface{}, userManager UserManager) ([]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if typ == fieldTypeArray {
+		arr, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: expected an array but was %T", errCustomFieldValue, value)
+		}
+		values := make([]string, 0, len(arr))
+		for _, each := range arr {
+			v, err := coerceCustomFieldScalar(items, each, userManager)
+			if err != nil {
+				return nil, err
+			}
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	}
+	v, err := coerceCustomFieldScalar(typ, value, userManager)
+	if err != nil || v == "" {
+		return nil, err
+	}
+	return []string{v}, nil
+}
+
+func coerceCustomFieldScalar(typ string, value interface{}, userManager UserManager) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	switch typ {
+	case fieldTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return "", fmt.Errorf("%w: expected a number but was %q", errCustomFieldValue, v)
+			}
+			return v, nil
+		}
+		return "", fmt.Errorf("%w: expected a number but was %T", errCustomFieldValue, value)
+	case fieldTypeDate, fieldTypeDatetime:
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: expected a date but was %T", errCustomFieldValue, value)
+		}
+		d, err := parseCustomFieldDate(s)
+		if err != nil {
+			return "", fmt.Errorf("%w: expected a date but was %q", errCustomFieldValue, s)
+		}
+		return d.Format("2006-01-02"), nil
+	case fieldTypeUser:
+		kv, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("%w: expected a user but was %T", errCustomFieldValue, value)
+		}
+		var u user
+		if err := sdk.MapToStruct(kv, &u); err != nil {
+			return "", fmt.Errorf("%w: error decoding user: %v", errCustomFieldValue, err)
+		}
+		if u.IsZero() {
+			return "", nil
+		}
+		if userManager != nil {
+			if err := userManager.Emit(u); err != nil {
+				return "", err
+			}
+		}
+		return u.RefID(), nil
+	}
+	// options and anything else we don't know the type of use the value, name or key of an object
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case map[string]interface{}:
+		for _, key := range []string{"value", "name", "displayName", "key"} {
+			if s, ok := v[key].(string); ok && s != "" {
+				return s, nil
+			}
+		}
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// applyCustomFieldMapping sets the values of a mapped custom field on the issue
+func applyCustomFieldMapping(issue *sdk.WorkIssue, field customField, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	switch field.Mapping.Target {
+	case mappingTargetStoryPoints:
+		sp, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return fmt.Errorf("%w: expected a number for story points but was %q", errCustomFieldValue, values[0])
+		}
+		issue.StoryPoints = &sp
+	case mappingTargetEpicName:
+		issue.EpicName = sdk.StringPointer(strings.Join(values, ", "))
+	case mappingTargetPlannedStartDate, mappingTargetPlannedEndDate, mappingTargetDueDate:
+		d, err := parseCustomFieldDate(values[0])
+		if err != nil {
+			return fmt.Errorf("%w: expected a date for %s but was %q", errCustomFieldValue, field.Mapping.Target, values[0])
+		}
+		switch field.Mapping.Target {
+		case mappingTargetPlannedStartDate:
+			sdk.ConvertTimeToDateModel(d, &issue.PlannedStartDate)
+		case mappingTargetPlannedEndDate:
+			sdk.ConvertTimeToDateModel(d, &issue.PlannedEndDate)
+		default:
+			sdk.ConvertTimeToDateModel(d, &issue.DueDate)
+		}
+	case mappingTargetAssignee:
+		issue.AssigneeRefID = values[0]
+	case mappingTargetTags:
+		issue.Tags = appendUnique(issue.Tags, field.tags(values)...)
+	}
+	return nil
+}
+
+// mappedChangeLogField returns the mapped custom field a change is for. server only has the name of the field
+func mappedChangeLogField(fields map[string]customField, item changeLogItem) (customField, bool) {
+	if item.FieldID != "" {
+		field, ok := fields[item.FieldID]
+		return field, ok && field.Mapping != nil
+	}
+	if item.FieldType != "custom" {
+		return customField{}, false
+	}
+	for _, field := range fields {
+		if field.Mapping != nil && strings.EqualFold(field.Name, item.Field) {
+			return field, true
+		}
+	}
+	return customField{}, false
+}
+
+// splitChangeLogValues splits the string of an array field in a change, which jira separates with commas
+func splitChangeLogValues(val string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(val, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// setMappedChangeLog sets the field and values for a change to a mapped custom field. it returns false for the targets
+// which don't have a changelog field
+func setMappedChangeLog(change *sdk.WorkIssueChangeLog, field customField, item changeLogItem) bool {
+	switch field.Mapping.Target {
+	case mappingTargetDueDate:
+		change.Field = sdk.WorkIssueChangeLogFieldDueDate
+		change.From = item.From
+		if change.From == "" {
+			change.From = item.FromString
+		}
+		change.To = item.To
+		if change.To == "" {
+			change.To = item.ToString
+		}
+	case mappingTargetAssignee:
+		change.Field = sdk.WorkIssueChangeLogFieldAssigneeRefID
+		change.From = item.From
+		change.To = item.To
+	case mappingTargetTags:
+		// tags are separated by spaces like they are for labels
+		change.Field = sdk.WorkIssueChangeLogFieldTags
+		change.From = strings.Join(field.tags(splitChangeLogValues(item.FromString)), " ")
+		change.To = strings.Join(field.tags(splitChangeLogValues(item.ToString)), " ")
+	default:
+		return false
+	}
+	return true
+}
+
+// hasTagsMapping returns true if any of the fields are mapped to the issue's tags
+func hasTagsMapping(fields map[string]customField) bool {
+	for _, field := range fields {
+		if field.Mapping != nil && field.Mapping.Target == mappingTargetTags {
+			return true
+		}
+	}
+	return false
+}
+
+// pushPullTags adds the tags which were added to the update's push and the ones which were removed to its pull, so that
+// the issue's other tags are kept
+func pushPullTags(val *sdk.WorkIssueUpdate, from []string, to []string) {
+	var push, pull []string
+	if val.Push.Tags != nil {
+		push = *val.Push.Tags
+	}
+	if val.Pull.Tags != nil {
+		pull = *val.Pull.Tags
+	}
+	for _, tag := range to {
+		if !sliceContains(from, tag) {
+			push = appendUnique(push, tag)
+		}
+	}
+	for _, tag := range from {
+		if !sliceContains(to, tag) {
+			pull = appendUnique(pull, tag)
+		}
+	}
+	if len(push) > 0 {
+		val.Push.Tags = &push
+	}
+	if len(pull) > 0 {
+		val.Pull.Tags = &pull
+	}
+}
+
+// setMappedWebhookUpdate sets the value of a change to a mapped custom field on the update for an issue webhook. the
+// epic name has nothing to update so is left for the next export
+func setMappedWebhookUpdate(val *sdk.WorkIssueUpdate, field customField, item changeLogItem) error {
+	// number and date custom fields only have their value in the string
+	to := item.To
+	if to == "" {
+		to = item.ToString
+	}
+	switch field.Mapping.Target {
+	case mappingTargetStoryPoints:
+		if item.ToString == "" {
+			val.Unset.StoryPoints = sdk.BoolPointer(true)
+			return nil
+		}
+		sp, err := strconv.ParseFloat(item.ToString, 32)
+		if err != nil {
+			return fmt.Errorf("%w: expected a number for story points but was %q", errCustomFieldValue, item.ToString)
+		}
+		storyPoints := float32(sp)
+		val.Set.StoryPoints = &storyPoints
+	case mappingTargetPlannedStartDate, mappingTargetPlannedEndDate, mappingTargetDueDate:
+		var d *time.Time
+		if to != "" {
+			t, err := parseCustomFieldDate(to)
+			if err != nil {
+				return fmt.Errorf("%w: expected a date for %s but was %q", errCustomFieldValue, field.Mapping.Target, to)
+			}
+			d = &t
+		}
+		switch field.Mapping.Target {
+		case mappingTargetPlannedStartDate:
+			val.Set.PlannedStartDate = d
+			if d == nil {
+				val.Unset.PlannedStartDate = sdk.BoolPointer(true)
+			}
+		case mappingTargetPlannedEndDate:
+			val.Set.PlannedEndDate = d
+			if d == nil {
+				val.Unset.PlannedEndDate = sdk.BoolPointer(true)
+			}
+		default:
+			val.Set.DueDate = d
+			if d == nil {
+				val.Unset.DueDate = sdk.BoolPointer(true)
+			}
+		}
+	case mappingTargetAssignee:
+		assignee := item.To
+		val.Set.AssigneeRefID = &assignee
+	case mappingTargetTags:
+		pushPullTags(val, field.tags(splitChangeLogValues(item.FromString)), field.tags(splitChangeLogValues(item.ToString)))
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/integration-sdk/agent"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCustomFields() map[string]customField {
+	return map[string]customField{
+		"customfield_10001": {ID: "customfield_10001", Name: "Team", Type: fieldTypeOption},
+		"customfield_10002": {ID: "customfield_10002", Name: "Story point estimate", Type: fieldTypeNumber},
+		"customfield_10003": {ID: "customfield_10003", Name: "Customer", Type: fieldTypeArray, Items: fieldTypeOption},
+		"customfield_10004": {ID: "customfield_10004", Name: "Owner", Type: fieldTypeUser},
+		"customfield_10005": {ID: "customfield_10005", Name: "Target Date", Type: fieldTypeDatetime},
+		"customfield_10006": {ID: "customfield_10006", Name: "Severity", Type: fieldTypeOption},
+		"customfield_10007": {ID: "customfield_10007", Name: "Severity", Type: fieldTypeString},
+	}
+}
+
+func TestCustomFieldMappings(t *testing.T) {
+	assert := assert.New(t)
+	mappings, err := customFieldMappings(sdk.NewConfig(nil))
+	assert.NoError(err)
+	assert.Empty(mappings)
+	mappings, err = customFieldMappings(sdk.NewConfig(map[string]interface{}{
+		configKeyCustomFieldMappings: `[{"field":"Team","target":"tags"},{"field":"customfield_10002","target":"story_points","type":"number"}]`,
+	}))
+	assert.NoError(err)
+	assert.Equal([]customFieldMapping{{Field: "Team", Target: mappingTargetTags}, {Field: "customfield_10002", Target: mappingTargetStoryPoints, Type: fieldTypeNumber}}, mappings)
+	// the config can also be an array
+	mappings, err = customFieldMappings(sdk.NewConfig(map[string]interface{}{
+		configKeyCustomFieldMappings: []interface{}{map[string]interface{}{"field": "Team", "target": "tags", "prefix": "team="}},
+	}))
+	assert.NoError(err)
+	assert.Len(mappings, 1)
+	assert.Equal("team=", *mappings[0].Prefix)
+	_, err = customFieldMappings(sdk.NewConfig(map[string]interface{}{configKeyCustomFieldMappings: `[{"field":"Team","target":"sprint"}]`}))
+	assert.EqualError(err, "custom field mapping for Team has an unknown target: sprint")
+	_, err = customFieldMappings(sdk.NewConfig(map[string]interface{}{configKeyCustomFieldMappings: `[{"field":"Team","target":"tags","type":"money"}]`}))
+	assert.EqualError(err, "custom field mapping for Team has an unknown type: money")
+}
+
+func TestFindCustomField(t *testing.T) {
+	assert := assert.New(t)
+	fields := newTestCustomFields()
+	id, err := findCustomField(fields, "customfield_10001")
+	assert.NoError(err)
+	assert.Equal("customfield_10001", id)
+	id, err = findCustomField(fields, "team")
+	assert.NoError(err)
+	assert.Equal("customfield_10001", id)
+	_, err = findCustomField(fields, "Severity")
+	assert.EqualError(err, "more than one custom field is named Severity, use one of the ids instead: customfield_10006, customfield_10007")
+	_, err = findCustomField(fields, "Nope")
+	assert.Error(err)
+}
+
+func TestCoerceCustomFieldValue(t *testing.T) {
+	assert := assert.New(t)
+	um := &mockUserManager{}
+	values, err := coerceCustomFieldValue(fieldTypeOption, "", map[string]interface{}{"id": "1", "value": "Platform"}, um)
+	assert.NoError(err)
+	assert.Equal([]string{"Platform"}, values)
+	values, err = coerceCustomFieldValue(fieldTypeNumber, "", 2.5, um)
+	assert.NoError(err)
+	assert.Equal([]string{"2.5"}, values)
+	values, err = coerceCustomFieldValue(fieldTypeDatetime, "", "2020-10-01T10:00:00.000+0000", um)
+	assert.NoError(err)
+	assert.Equal([]string{"2020-10-01"}, values)
+	values, err = coerceCustomFieldValue(fieldTypeArray, fieldTypeOption, []interface{}{map[string]interface{}{"value": "Acme"}, map[string]interface{}{"value": "Globex"}}, um)
+	assert.NoError(err)
+	assert.Equal([]string{"Acme", "Globex"}, values)
+	values, err = coerceCustomFieldValue(fieldTypeUser, "", map[string]interface{}{"accountId": "abc", "displayName": "Robin"}, um)
+	assert.NoError(err)
+	assert.Equal([]string{"abc"}, values)
+	assert.Len(um.users, 1)
+	values, err = coerceCustomFieldValue(fieldTypeString, "", nil, um)
+	assert.NoError(err)
+	assert.Empty(values)
+	_, err = coerceCustomFieldValue(fieldTypeNumber, "", "lots", um)
+	assert.True(errors.Is(err, errCustomFieldValue))
+	_, err = coerceCustomFieldValue(fieldTypeArray, fieldTypeOption, "Acme", um)
+	assert.True(errors.Is(err, errCustomFieldValue))
+}
+
+func TestIssueToModelMapsCustomFields(t *testing.T) {
+	assert := assert.New(t)
+	fields := newTestCustomFields()
+	config := sdk.NewConfig(map[string]interface{}{
+		configKeyCustomFieldMappings: `[
+			{"field":"Team","target":"tags"},
+			{"field":"Story point estimate","target":"story_points"},
+			{"field":"Customer","target":"tags","prefix":""},
+			{"field":"Owner","target":"assignee_ref_id"},
+			{"field":"Target Date","target":"planned_end_date"},
+			{"field":"customfield_10007","target":"tags","type":"number"},
+			{"field":"Deleted Field","target":"tags"}
+		]`,
+	})
+	assert.NoError(applyCustomFieldMappings(sdk.NewNoOpTestLogger(), config, fields))
+	assert.Nil(fields["customfield_10006"].Mapping)
+	source := issueSource{
+		ID:  "10000",
+		Key: "ABC-1",
+		Fields: map[string]interface{}{
+			"project":           map[string]interface{}{"id": "1", "key": "ABC"},
+			"labels":            []interface{}{"bug"},
+			"customfield_10001": map[string]interface{}{"value": "Platform"},
+			"customfield_10002": 3.0,
+			"customfield_10003": []interface{}{map[string]interface{}{"value": "Acme"}},
+			"customfield_10004": map[string]interface{}{"accountId": "abc"},
+			"customfield_10005": "2020-10-01T10:00:00.000+0000",
+			"customfield_10006": map[string]interface{}{"value": "High"},
+			"customfield_10007": "not a number",
+		},
+	}
+	um := &mockUserManager{}
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+	issue, _, err := source.ToModel("1234", "1", nil, sm, um, fields, findCustomFieldIDs(fields), "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	assert.Equal([]string{"bug", "Team:Platform", "Acme"}, issue.Tags)
+	assert.Equal(3.0, *issue.StoryPoints)
+	assert.Equal("abc", issue.AssigneeRefID)
+	// datetimes are mapped onto dates
+	assert.Equal("2020-10-01", sdk.DateFromEpoch(issue.PlannedEndDate.Epoch).UTC().Format("2006-01-02"))
+}
+
+func TestCreateChangeLogForMappedCustomFields(t *testing.T) {
+	assert := assert.New(t)
+	fields := newTestCustomFields()
+	config := sdk.NewConfig(map[string]interface{}{
+		configKeyCustomFieldMappings: `[{"field":"Customer","target":"tags"},{"field":"Owner","target":"assignee_ref_id"},{"field":"Story point estimate","target":"story_points"}]`,
+	})
+	assert.NoError(applyCustomFieldMappings(sdk.NewNoOpTestLogger(), config, fields))
+	c := createChangeLog("1234", "1", "robin", sdk.DateFromEpoch(0), 1, changeLogItem{Field: "Customer", FieldID: "customfield_10003", FieldType: "custom", FromString: "Acme", ToString: "Acme,Globex"}, fields, findCustomFieldIDs(fields))
+	assert.NotNil(c)
+	assert.Equal(sdk.WorkIssueChangeLogFieldTags, c.Field)
+	assert.Equal("Customer:Acme", c.From)
+	assert.Equal("Customer:Acme Customer:Globex", c.To)
+	// server only has the name of the field
+	c = createChangeLog("1234", "1", "robin", sdk.DateFromEpoch(0), 1, changeLogItem{Field: "Owner", FieldType: "custom", From: "a", To: "b"}, fields, findCustomFieldIDs(fields))
+	assert.NotNil(c)
+	assert.Equal(sdk.WorkIssueChangeLogFieldAssigneeRefID, c.Field)
+	assert.Equal("b", c.To)
+	// there's no changelog field for story points
+	assert.Nil(createChangeLog("1234", "1", "robin", sdk.DateFromEpoch(0), 1, changeLogItem{Field: "Story point estimate", FieldID: "customfield_10002", FieldType: "custom", To: "3"}, fields, findCustomFieldIDs(fields)))
+	// fields which aren't mapped are ignored
+	assert.Nil(createChangeLog("1234", "1", "robin", sdk.DateFromEpoch(0), 1, changeLogItem{Field: "Team", FieldID: "customfield_10001", FieldType: "custom", To: "1"}, fields, findCustomFieldIDs(fields)))
+}
+
+// withChangelogItems replaces the changelog items of a webhook
+func withChangelogItems(raw []byte, items ...changeLogItem) []byte {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		panic(err)
+	}
+	payload["changelog"].(map[string]interface{})["items"] = items
+	buf, _ := json.Marshal(payload)
+	return buf
+}
+
+func TestWebhookJiraIssueUpdatedMappedCustomFields(t *testing.T) {
+	assert := assert.New(t)
+	i := JiraIntegration{}
+	webhook := newMockWebHook("testdata/jira:issue_updated.tags.json")
+	webhook.config.Merge(map[string]interface{}{
+		configKeyCustomFieldMappings: `[{"field":"Team","target":"tags"},{"field":"Target start","target":"planned_start_date"}]`,
+	})
+	webhook.raw = withChangelogItems(webhook.raw,
+		changeLogItem{Field: "labels", FieldID: "labels", FieldType: "jira", FromString: "bug", ToString: "bug signal"},
+		changeLogItem{Field: "Team", FieldID: "customfield_10200", FieldType: "custom", From: "10300", FromString: "Platform", To: "10301", ToString: "Mobile"},
+		changeLogItem{Field: "Target start", FieldID: "customfield_10201", FieldType: "custom", ToString: "2020-10-05"},
+	)
+	assert.NoError(i.webhookUpdateIssue(sdk.NewNoOpTestLogger(), webhook))
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	// the labels are pushed and pulled so the mapped tags aren't replaced
+	assert.Empty(update.Set["tags"])
+	assert.EqualValues(`["signal","Team:Mobile"]`, update.Push["tags"])
+	assert.EqualValues(`["Team:Platform"]`, update.Pull["tags"])
+	assert.Contains(update.Set["planned_start_date"], `"epoch":1601856000000`)
+	var changes []sdk.WorkIssueChangeLog
+	assert.NoError(json.Unmarshal([]byte(update.Push["change_log"]), &changes))
+	assert.Len(changes, 2)
+	assert.Equal(sdk.WorkIssueChangeLogFieldTags, changes[1].Field)
+	assert.Equal("Team:Platform", changes[1].From)
+	assert.Equal("Team:Mobile", changes[1].To)
+}
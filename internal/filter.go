@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+// issueFilter returns the customer's issue filter or an empty string if all issues should be exported
+func issueFilter(config sdk.Config) string {
+	found, val := config.GetString(configKeyIssueFilter)
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(val)
+}
+
+var orderByRegexp = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// stripJQLStrings returns the jql with the contents of any quoted strings removed, or an error if a string isn't closed
+func stripJQLStrings(jql string) (string, error) {
+	var sb strings.Builder
+	var quote rune
+	var escaped bool
+	for _, r := range jql {
+		switch {
+		case escaped:
+			escaped = false
+			continue
+		case quote != 0 && r == '\\':
+			escaped = true
+			continue
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			continue
+		case r == '"' || r == '\'':
+			quote = r
+		}
+		sb.WriteRune(r)
+	}
+	if quote != 0 {
+		return "", errors.New("the issue filter has a quote which isn't closed")
+	}
+	return sb.String(), nil
+}
+
+// validateIssueFilter checks for things jira would accept in a query but which would break ours once combined
+func validateIssueFilter(filter string) error {
+	jql, err := stripJQLStrings(filter)
+	if err != nil {
+		return err
+	}
+	if orderByRegexp.MatchString(jql) {
+		return errors.New("the issue filter cannot contain an ORDER BY clause")
+	}
+	// a filter like x) OR (y would escape the parentheses we put it in
+	var depth int
+	for _, r := range jql {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return errors.New("the issue filter has a closing parenthesis without an opening one")
+			}
+		}
+	}
+	if depth != 0 {
+		return errors.New("the issue filter has a parenthesis which isn't closed")
+	}
+	return nil
+}
+
+// andIssueFilter will add the filter to the jql, which must not have an ORDER BY yet
+func andIssueFilter(jql string, filter string) string {
+	if filter == "" {
+		return jql
+	}
+	return jql + "AND (" + filter + ") "
+}
+
+// issueMatchesFilter returns true if the issue is included by the filter, asking jira so that we support any jql
+func (i *JiraIntegration) issueMatchesFilter(logger sdk.Logger, control sdk.Control, authConfig authConfig, filter string, issueRefID string) (bool, error) {
+	if filter == "" {
+		return true, nil
+	}
+	if err := validateIssueFilter(filter); err != nil {
+		return false, err
+	}
+	theurl := authConfig.restURL("/search")
+	client := i.httpmanager.New(theurl, nil)
+	qs := make(url.Values)
+	qs.Set("jql", "id = "+issueRefID+" AND ("+filter+")")
+	qs.Set("fields", "id")
+	qs.Set("maxResults", "0") // we only need the total
+	var resp issueQueryResult
+	if _, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
+		return false, err
+	}
+	return resp.Total > 0, nil
+}
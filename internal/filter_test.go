@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/integration-sdk/agent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueSearchJQLWithFilter(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("project in (10000,10001) ORDER BY updated DESC", issueSearchJQL([]string{"10000", "10001"}, time.Time{}, ""))
+	assert.Equal("project in (10000) AND (labels != hr-confidential OR labels is EMPTY) ORDER BY updated DESC", issueSearchJQL([]string{"10000"}, time.Time{}, "labels != hr-confidential OR labels is EMPTY"))
+}
+
+func TestValidateIssueFilter(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(validateIssueFilter(""))
+	assert.NoError(validateIssueFilter("labels != hr-confidential AND reporter = border"))
+	assert.Error(validateIssueFilter("labels != hr-confidential ORDER BY created"))
+	assert.Error(validateIssueFilter("type = Bug order\tby key"))
+	assert.NoError(validateIssueFilter(`summary ~ "order by" AND (labels = a OR labels = b)`))
+	assert.NoError(validateIssueFilter(`summary ~ "(" AND summary ~ 'it\'s )' AND summary ~ "say \"hi\""`))
+	assert.Error(validateIssueFilter("labels = a) OR (labels = b"))
+	assert.Error(validateIssueFilter("(labels = a"))
+	assert.Error(validateIssueFilter(`summary ~ "unclosed`))
+	assert.Error(validateIssueFilter(`summary ~ "x" ORDER BY key`))
+}
+
+func TestExportWithIssueFilter(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.filter = "labels != hr-confidential"
+	jira.excluded["100000002"] = true
+	jira.excluded["100000004"] = true
+
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyIssueFilter: " labels != hr-confidential "})
+	assert.NoError(newMockIntegration().Export(export))
+
+	assert.NotEmpty(jira.jqls)
+	for _, jql := range jira.jqls {
+		assert.Contains(jql, "AND (labels != hr-confidential)")
+	}
+	exported := make([]string, 0)
+	for _, object := range export.pipe.written {
+		if i, ok := object.(*sdk.WorkIssue); ok {
+			exported = append(exported, i.RefID)
+		}
+	}
+	assert.ElementsMatch([]string{"100000001", "100000003", "100000005"}, exported)
+}
+
+func TestExportRejectsIssueFilterWithOrderBy(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	export := newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyIssueFilter: "project = ABC ORDER BY key"})
+	assert.Error(newMockIntegration().Export(export))
+}
+
+func TestWebhookUpdateIssueExcludedByFilter(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	webhook := newMockWebHook("testdata/jira:issue_updated.assignee.json")
+	assert.NoError(webhook.config.Parse(makeMockAuth(jira.URL())))
+	webhook.config.Merge(map[string]interface{}{configKeyIssueFilter: "labels != hr-confidential"})
+	assert.NoError(newMockIntegration().webhookUpdateIssue(sdk.NewNoOpTestLogger(), webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.Equal("11917", update.RefID)
+	assert.EqualValues("false", update.Set["active"])
+	assert.Equal([]string{"id = 11917 AND (labels != hr-confidential)"}, jira.jqls)
+}
+
+func TestWebhookAttachmentExcludedByFilter(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	webhook := newMockWebHook("testdata/attachment_created.json")
+	assert.NoError(webhook.config.Parse(makeMockAuth(jira.URL())))
+	webhook.config.Merge(map[string]interface{}{configKeyIssueFilter: "labels != hr-confidential"})
+	assert.NoError(newMockIntegration().WebHook(webhook))
+	assert.Empty(webhook.pipe.Written)
+	assert.Equal([]string{"id = 20192 AND (labels != hr-confidential)"}, jira.jqls)
+}
+
+func TestValidateJQL(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.invalidJQL = "lables"
+	i := newMockIntegration()
+
+	result, err := i.Validate(newMockValidate(jira.URL(), map[string]interface{}{"action": ValidateJQL, "jql": "project = ABC"}))
+	assert.NoError(err)
+	assert.Equal(true, result["valid"])
+	assert.Equal(5, result["total"])
+
+	result, err = i.Validate(newMockValidate(jira.URL(), map[string]interface{}{"action": ValidateJQL, configKeyIssueFilter: "lables = foo"}))
+	assert.NoError(err)
+	assert.Equal(false, result["valid"])
+	assert.Equal([]string{"Error in the JQL Query: lables"}, result["errors"])
+
+	result, err = i.Validate(newMockValidate(jira.URL(), map[string]interface{}{"action": ValidateJQL, "jql": "project = ABC ORDER BY key"}))
+	assert.NoError(err)
+	assert.Equal(false, result["valid"])
+	assert.Len(jira.jqls, 2)
+}
@@ -29,7 +29,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal(in *jlexer.Lexer, out *web
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -95,7 +95,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal1(in *jlexer.Lexer, out *va
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -161,7 +161,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal2(in *jlexer.Lexer, out *us
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -239,7 +239,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal3(in *jlexer.Lexer, out *us
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -306,7 +306,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal4(in *jlexer.Lexer, out *us
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -437,7 +437,7 @@ func easyjson2a877177Decode(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -512,7 +512,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal5(in *jlexer.Lexer, out *tr
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -594,7 +594,7 @@ func easyjson2a877177Decode1(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -638,7 +638,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal6(in *jlexer.Lexer, out *st
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -711,7 +711,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal7(in *jlexer.Lexer, out *st
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -805,7 +805,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal8(in *jlexer.Lexer, out *sp
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -972,7 +972,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal9(in *jlexer.Lexer, out *sp
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1129,7 +1129,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal10(in *jlexer.Lexer, out *s
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1223,7 +1223,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal11(in *jlexer.Lexer, out *s
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1344,7 +1344,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal12(in *jlexer.Lexer, out *s
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1411,7 +1411,7 @@ func (v *setMutationOperation) UnmarshalJSON(data []byte) error {
 func (v *setMutationOperation) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson2a877177DecodeGithubComPinptJiraInternal12(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal13(in *jlexer.Lexer, out *projectSearchResult) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal13(in *jlexer.Lexer, out *serverInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1422,7 +1422,199 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal13(in *jlexer.Lexer, out *p
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "baseUrl":
+			out.BaseURL = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "versionNumbers":
+			if in.IsNull() {
+				in.Skip()
+				out.VersionNumbers = nil
+			} else {
+				in.Delim('[')
+				if out.VersionNumbers == nil {
+					if !in.IsDelim(']') {
+						out.VersionNumbers = make([]int, 0, 8)
+					} else {
+						out.VersionNumbers = []int{}
+					}
+				} else {
+					out.VersionNumbers = (out.VersionNumbers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 int
+					v4 = int(in.Int())
+					out.VersionNumbers = append(out.VersionNumbers, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "deploymentType":
+			out.DeploymentType = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal13(out *jwriter.Writer, in serverInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"baseUrl\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.BaseURL))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"versionNumbers\":"
+		out.RawString(prefix)
+		if in.VersionNumbers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.VersionNumbers {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"deploymentType\":"
+		out.RawString(prefix)
+		out.String(string(in.DeploymentType))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v serverInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v serverInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *serverInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *serverInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal13(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *securityLevel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal14(out *jwriter.Writer, in securityLevel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v securityLevel) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v securityLevel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *securityLevel) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *securityLevel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal14(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal15(in *jlexer.Lexer, out *projectSearchResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1448,7 +1640,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal13(in *jlexer.Lexer, out *p
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal13(out *jwriter.Writer, in projectSearchResult) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal15(out *jwriter.Writer, in projectSearchResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1478,27 +1670,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal13(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v projectSearchResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal13(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v projectSearchResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal13(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *projectSearchResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal13(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *projectSearchResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal13(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal15(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *projectQueryResult) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *projectQueryResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1509,7 +1701,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *p
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1527,7 +1719,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *p
 				in.Delim('[')
 				if out.Projects == nil {
 					if !in.IsDelim(']') {
-						out.Projects = make([]project, 0, 1)
+						out.Projects = make([]project, 0, 0)
 					} else {
 						out.Projects = []project{}
 					}
@@ -1535,9 +1727,9 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *p
 					out.Projects = (out.Projects)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v4 project
-					(v4).UnmarshalEasyJSON(in)
-					out.Projects = append(out.Projects, v4)
+					var v7 project
+					(v7).UnmarshalEasyJSON(in)
+					out.Projects = append(out.Projects, v7)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1552,7 +1744,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal14(in *jlexer.Lexer, out *p
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal14(out *jwriter.Writer, in projectQueryResult) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal16(out *jwriter.Writer, in projectQueryResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1568,11 +1760,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal14(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.Projects {
-				if v5 > 0 {
+			for v8, v9 := range in.Projects {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				(v6).MarshalEasyJSON(out)
+				(v9).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1583,27 +1775,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal14(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v projectQueryResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal14(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v projectQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal14(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *projectQueryResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal14(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *projectQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal14(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal16(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal15(in *jlexer.Lexer, out *project) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal17(in *jlexer.Lexer, out *projectIssueCreateMeta) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1614,7 +1806,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal15(in *jlexer.Lexer, out *p
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1622,117 +1814,35 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal15(in *jlexer.Lexer, out *p
 			continue
 		}
 		switch key {
-		case "expand":
-			out.Expand = string(in.String())
-		case "self":
-			out.Self = string(in.String())
 		case "id":
 			out.ID = string(in.String())
 		case "key":
 			out.Key = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "issueTypes":
-			if in.IsNull() {
-				in.Skip()
-				out.IssueTypes = nil
-			} else {
-				in.Delim('[')
-				if out.IssueTypes == nil {
-					if !in.IsDelim(']') {
-						out.IssueTypes = make([]struct {
-							Self        string `json:"self"`
-							ID          string `json:"id"`
-							Description string `json:"description"`
-							IconURL     string `json:"iconUrl"`
-							Name        string `json:"name"`
-							Subtask     bool   `json:"subtask"`
-							AvatarID    int    `json:"avatarId,omitempty"`
-						}, 0, 1)
-					} else {
-						out.IssueTypes = []struct {
-							Self        string `json:"self"`
-							ID          string `json:"id"`
-							Description string `json:"description"`
-							IconURL     string `json:"iconUrl"`
-							Name        string `json:"name"`
-							Subtask     bool   `json:"subtask"`
-							AvatarID    int    `json:"avatarId,omitempty"`
-						}{}
-					}
-				} else {
-					out.IssueTypes = (out.IssueTypes)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v7 struct {
-						Self        string `json:"self"`
-						ID          string `json:"id"`
-						Description string `json:"description"`
-						IconURL     string `json:"iconUrl"`
-						Name        string `json:"name"`
-						Subtask     bool   `json:"subtask"`
-						AvatarID    int    `json:"avatarId,omitempty"`
-					}
-					easyjson2a877177Decode2(in, &v7)
-					out.IssueTypes = append(out.IssueTypes, v7)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
 		case "name":
 			out.Name = string(in.String())
-		case "avatarUrls":
-			easyjson2a877177Decode3(in, &out.AvatarUrls)
-		case "projectKeys":
+		case "issuetypes":
 			if in.IsNull() {
 				in.Skip()
-				out.ProjectKeys = nil
+				out.Issuetypes = nil
 			} else {
 				in.Delim('[')
-				if out.ProjectKeys == nil {
+				if out.Issuetypes == nil {
 					if !in.IsDelim(']') {
-						out.ProjectKeys = make([]string, 0, 4)
+						out.Issuetypes = make([]createMetaIssueTypes, 0, 0)
 					} else {
-						out.ProjectKeys = []string{}
+						out.Issuetypes = []createMetaIssueTypes{}
 					}
 				} else {
-					out.ProjectKeys = (out.ProjectKeys)[:0]
+					out.Issuetypes = (out.Issuetypes)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 string
-					v8 = string(in.String())
-					out.ProjectKeys = append(out.ProjectKeys, v8)
+					var v10 createMetaIssueTypes
+					(v10).UnmarshalEasyJSON(in)
+					out.Issuetypes = append(out.Issuetypes, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "projectCategory":
-			easyjson2a877177Decode4(in, &out.ProjectCategory)
-		case "projectTypeKey":
-			out.ProjectTypeKey = string(in.String())
-		case "simplified":
-			out.Simplified = bool(in.Bool())
-		case "style":
-			out.Style = string(in.String())
-		case "isPrivate":
-			out.IsPrivate = bool(in.Bool())
-		case "entityId":
-			out.EntityID = string(in.String())
-		case "uuid":
-			out.UUID = string(in.String())
-		case "insight":
-			if in.IsNull() {
-				in.Skip()
-				out.Insight = nil
-			} else {
-				if out.Insight == nil {
-					out.Insight = new(struct {
-						TotalIssueCount     int    `json:"totalIssueCount"`
-						LastIssueUpdateTime string `json:"lastIssueUpdateTime"`
-					})
-				}
-				easyjson2a877177Decode5(in, out.Insight)
-			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1743,12 +1853,213 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal15(in *jlexer.Lexer, out *p
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal15(out *jwriter.Writer, in project) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal17(out *jwriter.Writer, in projectIssueCreateMeta) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"expand\":"
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix)
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"issuetypes\":"
+		out.RawString(prefix)
+		if in.Issuetypes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Issuetypes {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v projectIssueCreateMeta) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v projectIssueCreateMeta) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *projectIssueCreateMeta) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *projectIssueCreateMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal17(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal18(in *jlexer.Lexer, out *project) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "expand":
+			out.Expand = string(in.String())
+		case "self":
+			out.Self = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "key":
+			out.Key = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "issueTypes":
+			if in.IsNull() {
+				in.Skip()
+				out.IssueTypes = nil
+			} else {
+				in.Delim('[')
+				if out.IssueTypes == nil {
+					if !in.IsDelim(']') {
+						out.IssueTypes = make([]struct {
+							Self        string `json:"self"`
+							ID          string `json:"id"`
+							Description string `json:"description"`
+							IconURL     string `json:"iconUrl"`
+							Name        string `json:"name"`
+							Subtask     bool   `json:"subtask"`
+							AvatarID    int    `json:"avatarId,omitempty"`
+						}, 0, 0)
+					} else {
+						out.IssueTypes = []struct {
+							Self        string `json:"self"`
+							ID          string `json:"id"`
+							Description string `json:"description"`
+							IconURL     string `json:"iconUrl"`
+							Name        string `json:"name"`
+							Subtask     bool   `json:"subtask"`
+							AvatarID    int    `json:"avatarId,omitempty"`
+						}{}
+					}
+				} else {
+					out.IssueTypes = (out.IssueTypes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 struct {
+						Self        string `json:"self"`
+						ID          string `json:"id"`
+						Description string `json:"description"`
+						IconURL     string `json:"iconUrl"`
+						Name        string `json:"name"`
+						Subtask     bool   `json:"subtask"`
+						AvatarID    int    `json:"avatarId,omitempty"`
+					}
+					easyjson2a877177Decode2(in, &v13)
+					out.IssueTypes = append(out.IssueTypes, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "name":
+			out.Name = string(in.String())
+		case "avatarUrls":
+			easyjson2a877177Decode3(in, &out.AvatarUrls)
+		case "projectKeys":
+			if in.IsNull() {
+				in.Skip()
+				out.ProjectKeys = nil
+			} else {
+				in.Delim('[')
+				if out.ProjectKeys == nil {
+					if !in.IsDelim(']') {
+						out.ProjectKeys = make([]string, 0, 4)
+					} else {
+						out.ProjectKeys = []string{}
+					}
+				} else {
+					out.ProjectKeys = (out.ProjectKeys)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 string
+					v14 = string(in.String())
+					out.ProjectKeys = append(out.ProjectKeys, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "projectCategory":
+			easyjson2a877177Decode4(in, &out.ProjectCategory)
+		case "projectTypeKey":
+			out.ProjectTypeKey = string(in.String())
+		case "simplified":
+			out.Simplified = bool(in.Bool())
+		case "style":
+			out.Style = string(in.String())
+		case "isPrivate":
+			out.IsPrivate = bool(in.Bool())
+		case "entityId":
+			out.EntityID = string(in.String())
+		case "uuid":
+			out.UUID = string(in.String())
+		case "insight":
+			if in.IsNull() {
+				in.Skip()
+				out.Insight = nil
+			} else {
+				if out.Insight == nil {
+					out.Insight = new(struct {
+						TotalIssueCount     int    `json:"totalIssueCount"`
+						LastIssueUpdateTime string `json:"lastIssueUpdateTime"`
+					})
+				}
+				easyjson2a877177Decode5(in, out.Insight)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal18(out *jwriter.Writer, in project) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"expand\":"
 		out.RawString(prefix[1:])
 		out.String(string(in.Expand))
 	}
@@ -1779,11 +2090,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal15(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v9, v10 := range in.IssueTypes {
-				if v9 > 0 {
+			for v15, v16 := range in.IssueTypes {
+				if v15 > 0 {
 					out.RawByte(',')
 				}
-				easyjson2a877177Encode2(out, v10)
+				easyjson2a877177Encode2(out, v16)
 			}
 			out.RawByte(']')
 		}
@@ -1805,11 +2116,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal15(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v11, v12 := range in.ProjectKeys {
-				if v11 > 0 {
+			for v17, v18 := range in.ProjectKeys {
+				if v17 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v12))
+				out.String(string(v18))
 			}
 			out.RawByte(']')
 		}
@@ -1860,25 +2171,25 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal15(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v project) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal15(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v project) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal15(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *project) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal15(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *project) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal15(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal18(l, v)
 }
 func easyjson2a877177Decode5(in *jlexer.Lexer, out *struct {
 	TotalIssueCount     int    `json:"totalIssueCount"`
@@ -1894,7 +2205,7 @@ func easyjson2a877177Decode5(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -1951,7 +2262,7 @@ func easyjson2a877177Decode4(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2024,7 +2335,7 @@ func easyjson2a877177Decode3(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2100,7 +2411,7 @@ func easyjson2a877177Decode2(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2181,7 +2492,7 @@ func easyjson2a877177Encode2(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *mutationRequest) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal19(in *jlexer.Lexer, out *mutationRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2192,7 +2503,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *m
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2213,30 +2524,30 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *m
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v13 []setMutationOperation
+					var v19 []setMutationOperation
 					if in.IsNull() {
 						in.Skip()
-						v13 = nil
+						v19 = nil
 					} else {
 						in.Delim('[')
-						if v13 == nil {
+						if v19 == nil {
 							if !in.IsDelim(']') {
-								v13 = make([]setMutationOperation, 0, 4)
+								v19 = make([]setMutationOperation, 0, 4)
 							} else {
-								v13 = []setMutationOperation{}
+								v19 = []setMutationOperation{}
 							}
 						} else {
-							v13 = (v13)[:0]
+							v19 = (v19)[:0]
 						}
 						for !in.IsDelim(']') {
-							var v14 setMutationOperation
-							(v14).UnmarshalEasyJSON(in)
-							v13 = append(v13, v14)
+							var v20 setMutationOperation
+							(v20).UnmarshalEasyJSON(in)
+							v19 = append(v19, v20)
 							in.WantComma()
 						}
 						in.Delim(']')
 					}
-					(out.Update)[key] = v13
+					(out.Update)[key] = v19
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -2264,15 +2575,15 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *m
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v15 interface{}
-					if m, ok := v15.(easyjson.Unmarshaler); ok {
+					var v21 interface{}
+					if m, ok := v21.(easyjson.Unmarshaler); ok {
 						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v15.(json.Unmarshaler); ok {
+					} else if m, ok := v21.(json.Unmarshaler); ok {
 						_ = m.UnmarshalJSON(in.Raw())
 					} else {
-						v15 = in.Interface()
+						v21 = in.Interface()
 					}
-					(out.Fields)[key] = v15
+					(out.Fields)[key] = v21
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -2287,7 +2598,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal16(in *jlexer.Lexer, out *m
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal16(out *jwriter.Writer, in mutationRequest) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal19(out *jwriter.Writer, in mutationRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2297,24 +2608,24 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal16(out *jwriter.Writer, in
 		out.RawString(prefix[1:])
 		{
 			out.RawByte('{')
-			v16First := true
-			for v16Name, v16Value := range in.Update {
-				if v16First {
-					v16First = false
+			v22First := true
+			for v22Name, v22Value := range in.Update {
+				if v22First {
+					v22First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v16Name))
+				out.String(string(v22Name))
 				out.RawByte(':')
-				if v16Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+				if v22Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 					out.RawString("null")
 				} else {
 					out.RawByte('[')
-					for v17, v18 := range v16Value {
-						if v17 > 0 {
+					for v23, v24 := range v22Value {
+						if v23 > 0 {
 							out.RawByte(',')
 						}
-						(v18).MarshalEasyJSON(out)
+						(v24).MarshalEasyJSON(out)
 					}
 					out.RawByte(']')
 				}
@@ -2342,21 +2653,21 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal16(out *jwriter.Writer, in
 		}
 		{
 			out.RawByte('{')
-			v19First := true
-			for v19Name, v19Value := range in.Fields {
-				if v19First {
-					v19First = false
+			v25First := true
+			for v25Name, v25Value := range in.Fields {
+				if v25First {
+					v25First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v19Name))
+				out.String(string(v25Name))
 				out.RawByte(':')
-				if m, ok := v19Value.(easyjson.Marshaler); ok {
+				if m, ok := v25Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v19Value.(json.Marshaler); ok {
+				} else if m, ok := v25Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v19Value))
+					out.Raw(json.Marshal(v25Value))
 				}
 			}
 			out.RawByte('}')
@@ -2368,27 +2679,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal16(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v mutationRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal16(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v mutationRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal16(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *mutationRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal16(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *mutationRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal16(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal19(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal17(in *jlexer.Lexer, out *linkedIssue) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal20(in *jlexer.Lexer, out *linkedIssue) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2399,7 +2710,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal17(in *jlexer.Lexer, out *l
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2421,7 +2732,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal17(in *jlexer.Lexer, out *l
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal17(out *jwriter.Writer, in linkedIssue) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal20(out *jwriter.Writer, in linkedIssue) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2441,27 +2752,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal17(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v linkedIssue) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal17(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v linkedIssue) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal17(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *linkedIssue) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal17(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *linkedIssue) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal17(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal20(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal18(in *jlexer.Lexer, out *keyValue) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal21(in *jlexer.Lexer, out *keyValue) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2472,7 +2783,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal18(in *jlexer.Lexer, out *k
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2492,7 +2803,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal18(in *jlexer.Lexer, out *k
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal18(out *jwriter.Writer, in keyValue) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal21(out *jwriter.Writer, in keyValue) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2507,27 +2818,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal18(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v keyValue) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal18(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v keyValue) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal18(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *keyValue) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal18(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *keyValue) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal18(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal21(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal19(in *jlexer.Lexer, out *issuesErr) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal22(in *jlexer.Lexer, out *jiraErrResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2538,7 +2849,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal19(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2562,13 +2873,29 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal19(in *jlexer.Lexer, out *i
 					out.ErrorMessages = (out.ErrorMessages)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v20 string
-					v20 = string(in.String())
-					out.ErrorMessages = append(out.ErrorMessages, v20)
+					var v26 string
+					v26 = string(in.String())
+					out.ErrorMessages = append(out.ErrorMessages, v26)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Errors = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v27 string
+					v27 = string(in.String())
+					(out.Errors)[key] = v27
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2579,7 +2906,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal19(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal19(out *jwriter.Writer, in issuesErr) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal22(out *jwriter.Writer, in jiraErrResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2590,42 +2917,63 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal19(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v21, v22 := range in.ErrorMessages {
-				if v21 > 0 {
+			for v28, v29 := range in.ErrorMessages {
+				if v28 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v22))
+				out.String(string(v29))
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		if in.Errors == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v30First := true
+			for v30Name, v30Value := range in.Errors {
+				if v30First {
+					v30First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v30Name))
+				out.RawByte(':')
+				out.String(string(v30Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issuesErr) MarshalJSON() ([]byte, error) {
+func (v jiraErrResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal19(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issuesErr) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal19(w, v)
+func (v jiraErrResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issuesErr) UnmarshalJSON(data []byte) error {
+func (v *jiraErrResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal19(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issuesErr) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal19(l, v)
+func (v *jiraErrResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal22(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal20(in *jlexer.Lexer, out *issueTypesResult) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal23(in *jlexer.Lexer, out *issuesErr) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2636,7 +2984,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal20(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2644,10 +2992,29 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal20(in *jlexer.Lexer, out *i
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
+		case "errorMessages":
+			if in.IsNull() {
+				in.Skip()
+				out.ErrorMessages = nil
+			} else {
+				in.Delim('[')
+				if out.ErrorMessages == nil {
+					if !in.IsDelim(']') {
+						out.ErrorMessages = make([]string, 0, 4)
+					} else {
+						out.ErrorMessages = []string{}
+					}
+				} else {
+					out.ErrorMessages = (out.ErrorMessages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 string
+					v31 = string(in.String())
+					out.ErrorMessages = append(out.ErrorMessages, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2658,47 +3025,53 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal20(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal20(out *jwriter.Writer, in issueTypesResult) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal23(out *jwriter.Writer, in issuesErr) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"errorMessages\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
-	}
-	{
-		const prefix string = ",\"name\":"
-		out.RawString(prefix)
-		out.String(string(in.Name))
+		if in.ErrorMessages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v32, v33 := range in.ErrorMessages {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v33))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issueTypesResult) MarshalJSON() ([]byte, error) {
+func (v issuesErr) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal20(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal23(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issueTypesResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal20(w, v)
+func (v issuesErr) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal23(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issueTypesResult) UnmarshalJSON(data []byte) error {
+func (v *issuesErr) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal20(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal23(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issueTypesResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal20(l, v)
+func (v *issuesErr) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal23(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal21(in *jlexer.Lexer, out *issueType) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal24(in *jlexer.Lexer, out *issueTypesResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2709,7 +3082,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal21(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2721,12 +3094,6 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal21(in *jlexer.Lexer, out *i
 			out.ID = string(in.String())
 		case "name":
 			out.Name = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "iconUrl":
-			out.Icon = string(in.String())
-		case "subtask":
-			out.Subtask = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -2737,7 +3104,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal21(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal21(out *jwriter.Writer, in issueType) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal24(out *jwriter.Writer, in issueTypesResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2751,48 +3118,33 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal21(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.String(string(in.Name))
 	}
-	{
-		const prefix string = ",\"description\":"
-		out.RawString(prefix)
-		out.String(string(in.Description))
-	}
-	{
-		const prefix string = ",\"iconUrl\":"
-		out.RawString(prefix)
-		out.String(string(in.Icon))
-	}
-	{
-		const prefix string = ",\"subtask\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Subtask))
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issueType) MarshalJSON() ([]byte, error) {
+func (v issueTypesResult) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal21(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal24(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issueType) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal21(w, v)
+func (v issueTypesResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal24(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issueType) UnmarshalJSON(data []byte) error {
+func (v *issueTypesResult) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal21(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal24(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issueType) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal21(l, v)
+func (v *issueTypesResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal24(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal22(in *jlexer.Lexer, out *issueTransitionSource) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal25(in *jlexer.Lexer, out *issueTypeFieldSchema) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2803,7 +3155,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal22(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2811,29 +3163,12 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal22(in *jlexer.Lexer, out *i
 			continue
 		}
 		switch key {
-		case "transitions":
-			if in.IsNull() {
-				in.Skip()
-				out.Transitions = nil
-			} else {
-				in.Delim('[')
-				if out.Transitions == nil {
-					if !in.IsDelim(']') {
-						out.Transitions = make([]transitionSource, 0, 1)
-					} else {
-						out.Transitions = []transitionSource{}
-					}
-				} else {
-					out.Transitions = (out.Transitions)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v23 transitionSource
-					(v23).UnmarshalEasyJSON(in)
-					out.Transitions = append(out.Transitions, v23)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "type":
+			out.Type = string(in.String())
+		case "system":
+			out.System = string(in.String())
+		case "custom":
+			out.Custom = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2844,53 +3179,52 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal22(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal22(out *jwriter.Writer, in issueTransitionSource) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal25(out *jwriter.Writer, in issueTypeFieldSchema) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"transitions\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		if in.Transitions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v24, v25 := range in.Transitions {
-				if v24 > 0 {
-					out.RawByte(',')
-				}
-				(v25).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"system\":"
+		out.RawString(prefix)
+		out.String(string(in.System))
+	}
+	{
+		const prefix string = ",\"custom\":"
+		out.RawString(prefix)
+		out.String(string(in.Custom))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issueTransitionSource) MarshalJSON() ([]byte, error) {
+func (v issueTypeFieldSchema) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal22(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal25(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issueTransitionSource) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal22(w, v)
+func (v issueTypeFieldSchema) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal25(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issueTransitionSource) UnmarshalJSON(data []byte) error {
+func (v *issueTypeFieldSchema) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal22(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal25(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issueTransitionSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal22(l, v)
+func (v *issueTypeFieldSchema) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal25(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal23(in *jlexer.Lexer, out *issueSource) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal26(in *jlexer.Lexer, out *issueTypeField) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2901,7 +3235,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal23(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -2909,56 +3243,19 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal23(in *jlexer.Lexer, out *i
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
+		case "required":
+			out.Required = bool(in.Bool())
+		case "schema":
+			(out.Schema).UnmarshalEasyJSON(in)
+		case "name":
+			out.Name = string(in.String())
 		case "key":
 			out.Key = string(in.String())
-		case "fields":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				in.Delim('{')
-				out.Fields = make(map[string]interface{})
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v26 interface{}
-					if m, ok := v26.(easyjson.Unmarshaler); ok {
-						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v26.(json.Unmarshaler); ok {
-						_ = m.UnmarshalJSON(in.Raw())
-					} else {
-						v26 = in.Interface()
-					}
-					(out.Fields)[key] = v26
-					in.WantComma()
-				}
-				in.Delim('}')
-			}
-		case "changelog":
-			easyjson2a877177Decode6(in, &out.Changelog)
-		case "transitions":
-			if in.IsNull() {
-				in.Skip()
-				out.Transitions = nil
-			} else {
-				in.Delim('[')
-				if out.Transitions == nil {
-					if !in.IsDelim(']') {
-						out.Transitions = make([]transitionSource, 0, 1)
-					} else {
-						out.Transitions = []transitionSource{}
-					}
-				} else {
-					out.Transitions = (out.Transitions)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v27 transitionSource
-					(v27).UnmarshalEasyJSON(in)
-					out.Transitions = append(out.Transitions, v27)
-					in.WantComma()
-				}
-				in.Delim(']')
+		case "hasDefaultValue":
+			out.HasDefaultValue = bool(in.Bool())
+		case "allowedValues":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.AllowedValues).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -2970,102 +3267,67 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal23(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal23(out *jwriter.Writer, in issueSource) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal26(out *jwriter.Writer, in issueTypeField) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"required\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		out.Bool(bool(in.Required))
 	}
 	{
-		const prefix string = ",\"key\":"
+		const prefix string = ",\"schema\":"
 		out.RawString(prefix)
-		out.String(string(in.Key))
+		(in.Schema).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"fields\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		if in.Fields == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-			out.RawString(`null`)
-		} else {
-			out.RawByte('{')
-			v28First := true
-			for v28Name, v28Value := range in.Fields {
-				if v28First {
-					v28First = false
-				} else {
-					out.RawByte(',')
-				}
-				out.String(string(v28Name))
-				out.RawByte(':')
-				if m, ok := v28Value.(easyjson.Marshaler); ok {
-					m.MarshalEasyJSON(out)
-				} else if m, ok := v28Value.(json.Marshaler); ok {
-					out.Raw(m.MarshalJSON())
-				} else {
-					out.Raw(json.Marshal(v28Value))
-				}
-			}
-			out.RawByte('}')
-		}
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"changelog\":"
+		const prefix string = ",\"key\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode6(out, in.Changelog)
+		out.String(string(in.Key))
 	}
 	{
-		const prefix string = ",\"transitions\":"
+		const prefix string = ",\"hasDefaultValue\":"
 		out.RawString(prefix)
-		if in.Transitions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v29, v30 := range in.Transitions {
-				if v29 > 0 {
-					out.RawByte(',')
-				}
-				(v30).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.Bool(bool(in.HasDefaultValue))
+	}
+	if len(in.AllowedValues) != 0 {
+		const prefix string = ",\"allowedValues\":"
+		out.RawString(prefix)
+		out.Raw((in.AllowedValues).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issueSource) MarshalJSON() ([]byte, error) {
+func (v issueTypeField) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal23(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issueSource) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal23(w, v)
+func (v issueTypeField) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issueSource) UnmarshalJSON(data []byte) error {
+func (v *issueTypeField) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal23(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issueSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal23(l, v)
+func (v *issueTypeField) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal26(l, v)
 }
-func easyjson2a877177Decode6(in *jlexer.Lexer, out *struct {
-	Histories []struct {
-		ID      string          `json:"id"`
-		Author  user            `json:"author"`
-		Created string          `json:"created"`
-		Items   []changeLogItem `json:"items"`
-	} `json:"histories"`
-}) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *issueType) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3076,7 +3338,7 @@ func easyjson2a877177Decode6(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3084,44 +3346,18 @@ func easyjson2a877177Decode6(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "histories":
-			if in.IsNull() {
-				in.Skip()
-				out.Histories = nil
-			} else {
-				in.Delim('[')
-				if out.Histories == nil {
-					if !in.IsDelim(']') {
-						out.Histories = make([]struct {
-							ID      string          `json:"id"`
-							Author  user            `json:"author"`
-							Created string          `json:"created"`
-							Items   []changeLogItem `json:"items"`
-						}, 0, 1)
-					} else {
-						out.Histories = []struct {
-							ID      string          `json:"id"`
-							Author  user            `json:"author"`
-							Created string          `json:"created"`
-							Items   []changeLogItem `json:"items"`
-						}{}
-					}
-				} else {
-					out.Histories = (out.Histories)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v31 struct {
-						ID      string          `json:"id"`
-						Author  user            `json:"author"`
-						Created string          `json:"created"`
-						Items   []changeLogItem `json:"items"`
-					}
-					easyjson2a877177Decode7(in, &v31)
-					out.Histories = append(out.Histories, v31)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "iconUrl":
+			out.Icon = string(in.String())
+		case "subtask":
+			out.Subtask = bool(in.Bool())
+		case "hierarchyLevel":
+			out.HierarchyLevel = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -3132,41 +3368,67 @@ func easyjson2a877177Decode6(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode6(out *jwriter.Writer, in struct {
-	Histories []struct {
-		ID      string          `json:"id"`
-		Author  user            `json:"author"`
-		Created string          `json:"created"`
-		Items   []changeLogItem `json:"items"`
-	} `json:"histories"`
-}) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in issueType) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"histories\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		if in.Histories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v32, v33 := range in.Histories {
-				if v32 > 0 {
-					out.RawByte(',')
-				}
-				easyjson2a877177Encode7(out, v33)
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"iconUrl\":"
+		out.RawString(prefix)
+		out.String(string(in.Icon))
+	}
+	{
+		const prefix string = ",\"subtask\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Subtask))
+	}
+	{
+		const prefix string = ",\"hierarchyLevel\":"
+		out.RawString(prefix)
+		out.Int(int(in.HierarchyLevel))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode7(in *jlexer.Lexer, out *struct {
-	ID      string          `json:"id"`
-	Author  user            `json:"author"`
-	Created string          `json:"created"`
-	Items   []changeLogItem `json:"items"`
-}) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v issueType) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v issueType) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *issueType) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *issueType) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal27(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal28(in *jlexer.Lexer, out *issueTransitionSource) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3177,7 +3439,7 @@ func easyjson2a877177Decode7(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3185,31 +3447,25 @@ func easyjson2a877177Decode7(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "author":
-			(out.Author).UnmarshalEasyJSON(in)
-		case "created":
-			out.Created = string(in.String())
-		case "items":
+		case "transitions":
 			if in.IsNull() {
 				in.Skip()
-				out.Items = nil
+				out.Transitions = nil
 			} else {
 				in.Delim('[')
-				if out.Items == nil {
+				if out.Transitions == nil {
 					if !in.IsDelim(']') {
-						out.Items = make([]changeLogItem, 0, 1)
+						out.Transitions = make([]transitionSource, 0, 1)
 					} else {
-						out.Items = []changeLogItem{}
+						out.Transitions = []transitionSource{}
 					}
 				} else {
-					out.Items = (out.Items)[:0]
+					out.Transitions = (out.Transitions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v34 changeLogItem
+					var v34 transitionSource
 					(v34).UnmarshalEasyJSON(in)
-					out.Items = append(out.Items, v34)
+					out.Transitions = append(out.Transitions, v34)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3224,38 +3480,18 @@ func easyjson2a877177Decode7(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode7(out *jwriter.Writer, in struct {
-	ID      string          `json:"id"`
-	Author  user            `json:"author"`
-	Created string          `json:"created"`
-	Items   []changeLogItem `json:"items"`
-}) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal28(out *jwriter.Writer, in issueTransitionSource) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"transitions\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
-	}
-	{
-		const prefix string = ",\"author\":"
-		out.RawString(prefix)
-		(in.Author).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"created\":"
-		out.RawString(prefix)
-		out.String(string(in.Created))
-	}
-	{
-		const prefix string = ",\"items\":"
-		out.RawString(prefix)
-		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Transitions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v35, v36 := range in.Items {
+			for v35, v36 := range in.Transitions {
 				if v35 > 0 {
 					out.RawByte(',')
 				}
@@ -3266,7 +3502,31 @@ func easyjson2a877177Encode7(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal24(in *jlexer.Lexer, out *issueQueryResult) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v issueTransitionSource) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v issueTransitionSource) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *issueTransitionSource) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *issueTransitionSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal28(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal29(in *jlexer.Lexer, out *issueSource) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3277,7 +3537,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal24(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3285,27 +3545,53 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal24(in *jlexer.Lexer, out *i
 			continue
 		}
 		switch key {
-		case "total":
-			out.Total = int(in.Int())
-		case "issues":
+		case "id":
+			out.ID = string(in.String())
+		case "key":
+			out.Key = string(in.String())
+		case "fields":
 			if in.IsNull() {
 				in.Skip()
-				out.Issues = nil
+			} else {
+				in.Delim('{')
+				out.Fields = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v37 interface{}
+					if m, ok := v37.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v37.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v37 = in.Interface()
+					}
+					(out.Fields)[key] = v37
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "changelog":
+			easyjson2a877177Decode6(in, &out.Changelog)
+		case "transitions":
+			if in.IsNull() {
+				in.Skip()
+				out.Transitions = nil
 			} else {
 				in.Delim('[')
-				if out.Issues == nil {
+				if out.Transitions == nil {
 					if !in.IsDelim(']') {
-						out.Issues = make([]issueSource, 0, 1)
+						out.Transitions = make([]transitionSource, 0, 1)
 					} else {
-						out.Issues = []issueSource{}
+						out.Transitions = []transitionSource{}
 					}
 				} else {
-					out.Issues = (out.Issues)[:0]
+					out.Transitions = (out.Transitions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v37 issueSource
-					(v37).UnmarshalEasyJSON(in)
-					out.Issues = append(out.Issues, v37)
+					var v38 transitionSource
+					(v38).UnmarshalEasyJSON(in)
+					out.Transitions = append(out.Transitions, v38)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3320,27 +3606,64 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal24(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal24(out *jwriter.Writer, in issueQueryResult) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal29(out *jwriter.Writer, in issueSource) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"total\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Total))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"issues\":"
+		const prefix string = ",\"key\":"
 		out.RawString(prefix)
-		if in.Issues == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"fields\":"
+		out.RawString(prefix)
+		if in.Fields == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v39First := true
+			for v39Name, v39Value := range in.Fields {
+				if v39First {
+					v39First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v39Name))
+				out.RawByte(':')
+				if m, ok := v39Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v39Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v39Value))
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"changelog\":"
+		out.RawString(prefix)
+		easyjson2a877177Encode6(out, in.Changelog)
+	}
+	{
+		const prefix string = ",\"transitions\":"
+		out.RawString(prefix)
+		if in.Transitions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v38, v39 := range in.Issues {
-				if v38 > 0 {
+			for v40, v41 := range in.Transitions {
+				if v40 > 0 {
 					out.RawByte(',')
 				}
-				(v39).MarshalEasyJSON(out)
+				(v41).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3349,29 +3672,34 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal24(out *jwriter.Writer, in
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v issueQueryResult) MarshalJSON() ([]byte, error) {
+func (v issueSource) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal24(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal29(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v issueQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal24(w, v)
+func (v issueSource) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal29(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *issueQueryResult) UnmarshalJSON(data []byte) error {
+func (v *issueSource) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal24(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal29(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *issueQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal24(l, v)
+func (v *issueSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal29(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal25(in *jlexer.Lexer, out *issuePriority) {
+func easyjson2a877177Decode6(in *jlexer.Lexer, out *struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Histories  []changeLogHistory `json:"histories"`
+}) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3382,7 +3710,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal25(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3390,34 +3718,239 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal25(in *jlexer.Lexer, out *i
 			continue
 		}
 		switch key {
-		case "statusColor":
-			out.StatusColor = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "iconUrl":
-			out.IconURL = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "id":
-			out.ID = string(in.String())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson2a877177EncodeGithubComPinptJiraInternal25(out *jwriter.Writer, in issuePriority) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"statusColor\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.StatusColor))
+		case "startAt":
+			out.StartAt = int(in.Int())
+		case "maxResults":
+			out.MaxResults = int(in.Int())
+		case "total":
+			out.Total = int(in.Int())
+		case "histories":
+			if in.IsNull() {
+				in.Skip()
+				out.Histories = nil
+			} else {
+				in.Delim('[')
+				if out.Histories == nil {
+					if !in.IsDelim(']') {
+						out.Histories = make([]changeLogHistory, 0, 0)
+					} else {
+						out.Histories = []changeLogHistory{}
+					}
+				} else {
+					out.Histories = (out.Histories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v42 changeLogHistory
+					(v42).UnmarshalEasyJSON(in)
+					out.Histories = append(out.Histories, v42)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177Encode6(out *jwriter.Writer, in struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Histories  []changeLogHistory `json:"histories"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"startAt\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.StartAt))
+	}
+	{
+		const prefix string = ",\"maxResults\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxResults))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"histories\":"
+		out.RawString(prefix)
+		if in.Histories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v43, v44 := range in.Histories {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				(v44).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal30(in *jlexer.Lexer, out *issueQueryResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int(in.Int())
+		case "issues":
+			if in.IsNull() {
+				in.Skip()
+				out.Issues = nil
+			} else {
+				in.Delim('[')
+				if out.Issues == nil {
+					if !in.IsDelim(']') {
+						out.Issues = make([]issueSource, 0, 0)
+					} else {
+						out.Issues = []issueSource{}
+					}
+				} else {
+					out.Issues = (out.Issues)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v45 issueSource
+					(v45).UnmarshalEasyJSON(in)
+					out.Issues = append(out.Issues, v45)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal30(out *jwriter.Writer, in issueQueryResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"issues\":"
+		out.RawString(prefix)
+		if in.Issues == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v46, v47 := range in.Issues {
+				if v46 > 0 {
+					out.RawByte(',')
+				}
+				(v47).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v issueQueryResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v issueQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *issueQueryResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *issueQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal30(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal31(in *jlexer.Lexer, out *issuePriority) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "statusColor":
+			out.StatusColor = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "iconUrl":
+			out.IconURL = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal31(out *jwriter.Writer, in issuePriority) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"statusColor\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.StatusColor))
 	}
 	{
 		const prefix string = ",\"description\":"
@@ -3445,27 +3978,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal25(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v issuePriority) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal25(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal31(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v issuePriority) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal25(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal31(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *issuePriority) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal25(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal31(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *issuePriority) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal25(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal31(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal26(in *jlexer.Lexer, out *issueMover) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal32(in *jlexer.Lexer, out *issueMover) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3476,7 +4009,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal26(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3500,9 +4033,9 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal26(in *jlexer.Lexer, out *i
 					out.IssueRefIDs = (out.IssueRefIDs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v40 string
-					v40 = string(in.String())
-					out.IssueRefIDs = append(out.IssueRefIDs, v40)
+					var v48 string
+					v48 = string(in.String())
+					out.IssueRefIDs = append(out.IssueRefIDs, v48)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3517,7 +4050,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal26(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal26(out *jwriter.Writer, in issueMover) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal32(out *jwriter.Writer, in issueMover) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3528,11 +4061,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal26(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v41, v42 := range in.IssueRefIDs {
-				if v41 > 0 {
+			for v49, v50 := range in.IssueRefIDs {
+				if v49 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v42))
+				out.String(string(v50))
 			}
 			out.RawByte(']')
 		}
@@ -3543,27 +4076,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal26(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v issueMover) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal26(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal32(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v issueMover) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal26(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal32(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *issueMover) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal26(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal32(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *issueMover) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal26(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal32(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *issueFields) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal33(in *jlexer.Lexer, out *issueFields) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3574,7 +4107,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3583,13 +4116,13 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 		}
 		switch key {
 		case "project":
-			easyjson2a877177Decode8(in, &out.Project)
+			easyjson2a877177Decode7(in, &out.Project)
 		case "description":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.Description).UnmarshalJSON(data))
 			}
 		case "comment":
-			easyjson2a877177Decode9(in, &out.Comment)
+			(out.Comment).UnmarshalEasyJSON(in)
 		case "summary":
 			out.Summary = string(in.String())
 		case "duedate":
@@ -3609,16 +4142,16 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 						Key string `json:"key"`
 					})
 				}
-				easyjson2a877177Decode8(in, out.Parent)
+				easyjson2a877177Decode7(in, out.Parent)
 			}
 		case "priority":
-			easyjson2a877177Decode10(in, &out.Priority)
+			easyjson2a877177Decode8(in, &out.Priority)
 		case "issuetype":
-			easyjson2a877177Decode10(in, &out.IssueType)
+			easyjson2a877177Decode9(in, &out.IssueType)
 		case "status":
-			easyjson2a877177Decode11(in, &out.Status)
+			easyjson2a877177Decode10(in, &out.Status)
 		case "resolution":
-			easyjson2a877177Decode12(in, &out.Resolution)
+			easyjson2a877177Decode11(in, &out.Resolution)
 		case "creator":
 			(out.Creator).UnmarshalEasyJSON(in)
 		case "reporter":
@@ -3641,9 +4174,9 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 					out.Labels = (out.Labels)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v43 string
-					v43 = string(in.String())
-					out.Labels = append(out.Labels, v43)
+					var v51 string
+					v51 = string(in.String())
+					out.Labels = append(out.Labels, v51)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3663,7 +4196,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 							} `json:"type"`
 							OutwardIssue linkedIssue `json:"outwardIssue"`
 							InwardIssue  linkedIssue `json:"inwardIssue"`
-						}, 0, 1)
+						}, 0, 0)
 					} else {
 						out.IssueLinks = []struct {
 							ID   string `json:"id"`
@@ -3678,7 +4211,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 					out.IssueLinks = (out.IssueLinks)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v44 struct {
+					var v52 struct {
 						ID   string `json:"id"`
 						Type struct {
 							Name string `json:"name"`
@@ -3686,8 +4219,8 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 						OutwardIssue linkedIssue `json:"outwardIssue"`
 						InwardIssue  linkedIssue `json:"inwardIssue"`
 					}
-					easyjson2a877177Decode13(in, &v44)
-					out.IssueLinks = append(out.IssueLinks, v44)
+					easyjson2a877177Decode12(in, &v52)
+					out.IssueLinks = append(out.IssueLinks, v52)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3700,57 +4233,31 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 				in.Delim('[')
 				if out.Attachment == nil {
 					if !in.IsDelim(']') {
-						out.Attachment = make([]struct {
-							ID       string `json:"id"`
-							Filename string `json:"filename"`
-							Author   struct {
-								Key       string `json:"key"`
-								AccountID string `json:"accountId"`
-							} `json:"author"`
-							Created   string `json:"created"`
-							Size      int    `json:"size"`
-							MimeType  string `json:"mimeType"`
-							Content   string `json:"content"`
-							Thumbnail string `json:"thumbnail"`
-						}, 0, 1)
+						out.Attachment = make([]attachment, 0, 0)
 					} else {
-						out.Attachment = []struct {
-							ID       string `json:"id"`
-							Filename string `json:"filename"`
-							Author   struct {
-								Key       string `json:"key"`
-								AccountID string `json:"accountId"`
-							} `json:"author"`
-							Created   string `json:"created"`
-							Size      int    `json:"size"`
-							MimeType  string `json:"mimeType"`
-							Content   string `json:"content"`
-							Thumbnail string `json:"thumbnail"`
-						}{}
+						out.Attachment = []attachment{}
 					}
 				} else {
 					out.Attachment = (out.Attachment)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v45 struct {
-						ID       string `json:"id"`
-						Filename string `json:"filename"`
-						Author   struct {
-							Key       string `json:"key"`
-							AccountID string `json:"accountId"`
-						} `json:"author"`
-						Created   string `json:"created"`
-						Size      int    `json:"size"`
-						MimeType  string `json:"mimeType"`
-						Content   string `json:"content"`
-						Thumbnail string `json:"thumbnail"`
-					}
-					easyjson2a877177Decode14(in, &v45)
-					out.Attachment = append(out.Attachment, v45)
+					var v53 attachment
+					(v53).UnmarshalEasyJSON(in)
+					out.Attachment = append(out.Attachment, v53)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "security":
+			if in.IsNull() {
+				in.Skip()
+				out.Security = nil
+			} else {
+				if out.Security == nil {
+					out.Security = new(securityLevel)
+				}
+				(*out.Security).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3761,14 +4268,14 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal27(in *jlexer.Lexer, out *i
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in issueFields) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal33(out *jwriter.Writer, in issueFields) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"project\":"
 		out.RawString(prefix[1:])
-		easyjson2a877177Encode8(out, in.Project)
+		easyjson2a877177Encode7(out, in.Project)
 	}
 	{
 		const prefix string = ",\"description\":"
@@ -3778,7 +4285,7 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in
 	{
 		const prefix string = ",\"comment\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode9(out, in.Comment)
+		(in.Comment).MarshalEasyJSON(out)
 	}
 	{
 		const prefix string = ",\"summary\":"
@@ -3803,27 +4310,27 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in
 	if in.Parent != nil {
 		const prefix string = ",\"parent\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode8(out, *in.Parent)
+		easyjson2a877177Encode7(out, *in.Parent)
 	}
 	{
 		const prefix string = ",\"priority\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode10(out, in.Priority)
+		easyjson2a877177Encode8(out, in.Priority)
 	}
 	{
 		const prefix string = ",\"issuetype\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode10(out, in.IssueType)
+		easyjson2a877177Encode9(out, in.IssueType)
 	}
 	{
 		const prefix string = ",\"status\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode11(out, in.Status)
+		easyjson2a877177Encode10(out, in.Status)
 	}
 	{
 		const prefix string = ",\"resolution\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode12(out, in.Resolution)
+		easyjson2a877177Encode11(out, in.Resolution)
 	}
 	{
 		const prefix string = ",\"creator\":"
@@ -3847,11 +4354,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v46, v47 := range in.Labels {
-				if v46 > 0 {
+			for v54, v55 := range in.Labels {
+				if v54 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v47))
+				out.String(string(v55))
 			}
 			out.RawByte(']')
 		}
@@ -3863,11 +4370,11 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v48, v49 := range in.IssueLinks {
-				if v48 > 0 {
+			for v56, v57 := range in.IssueLinks {
+				if v56 > 0 {
 					out.RawByte(',')
 				}
-				easyjson2a877177Encode13(out, v49)
+				easyjson2a877177Encode12(out, v57)
 			}
 			out.RawByte(']')
 		}
@@ -3879,53 +4386,57 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal27(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v50, v51 := range in.Attachment {
-				if v50 > 0 {
+			for v58, v59 := range in.Attachment {
+				if v58 > 0 {
 					out.RawByte(',')
 				}
-				easyjson2a877177Encode14(out, v51)
+				(v59).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"security\":"
+		out.RawString(prefix)
+		if in.Security == nil {
+			out.RawString("null")
+		} else {
+			(*in.Security).MarshalEasyJSON(out)
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v issueFields) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal27(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal33(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v issueFields) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal27(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal33(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *issueFields) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal27(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal33(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *issueFields) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal27(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal33(l, v)
 }
-func easyjson2a877177Decode14(in *jlexer.Lexer, out *struct {
-	ID       string `json:"id"`
-	Filename string `json:"filename"`
-	Author   struct {
-		Key       string `json:"key"`
-		AccountID string `json:"accountId"`
-	} `json:"author"`
-	Created   string `json:"created"`
-	Size      int    `json:"size"`
-	MimeType  string `json:"mimeType"`
-	Content   string `json:"content"`
-	Thumbnail string `json:"thumbnail"`
+func easyjson2a877177Decode12(in *jlexer.Lexer, out *struct {
+	ID   string `json:"id"`
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	OutwardIssue linkedIssue `json:"outwardIssue"`
+	InwardIssue  linkedIssue `json:"inwardIssue"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -3937,7 +4448,7 @@ func easyjson2a877177Decode14(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -3947,20 +4458,12 @@ func easyjson2a877177Decode14(in *jlexer.Lexer, out *struct {
 		switch key {
 		case "id":
 			out.ID = string(in.String())
-		case "filename":
-			out.Filename = string(in.String())
-		case "author":
-			easyjson2a877177Decode15(in, &out.Author)
-		case "created":
-			out.Created = string(in.String())
-		case "size":
-			out.Size = int(in.Int())
-		case "mimeType":
-			out.MimeType = string(in.String())
-		case "content":
-			out.Content = string(in.String())
-		case "thumbnail":
-			out.Thumbnail = string(in.String())
+		case "type":
+			easyjson2a877177Decode11(in, &out.Type)
+		case "outwardIssue":
+			(out.OutwardIssue).UnmarshalEasyJSON(in)
+		case "inwardIssue":
+			(out.InwardIssue).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -3971,18 +4474,13 @@ func easyjson2a877177Decode14(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode14(out *jwriter.Writer, in struct {
-	ID       string `json:"id"`
-	Filename string `json:"filename"`
-	Author   struct {
-		Key       string `json:"key"`
-		AccountID string `json:"accountId"`
-	} `json:"author"`
-	Created   string `json:"created"`
-	Size      int    `json:"size"`
-	MimeType  string `json:"mimeType"`
-	Content   string `json:"content"`
-	Thumbnail string `json:"thumbnail"`
+func easyjson2a877177Encode12(out *jwriter.Writer, in struct {
+	ID   string `json:"id"`
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	OutwardIssue linkedIssue `json:"outwardIssue"`
+	InwardIssue  linkedIssue `json:"inwardIssue"`
 }) {
 	out.RawByte('{')
 	first := true
@@ -3993,45 +4491,71 @@ func easyjson2a877177Encode14(out *jwriter.Writer, in struct {
 		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"filename\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		out.String(string(in.Filename))
+		easyjson2a877177Encode11(out, in.Type)
 	}
 	{
-		const prefix string = ",\"author\":"
+		const prefix string = ",\"outwardIssue\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode15(out, in.Author)
+		(in.OutwardIssue).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"created\":"
+		const prefix string = ",\"inwardIssue\":"
 		out.RawString(prefix)
-		out.String(string(in.Created))
+		(in.InwardIssue).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"size\":"
-		out.RawString(prefix)
-		out.Int(int(in.Size))
+	out.RawByte('}')
+}
+func easyjson2a877177Decode11(in *jlexer.Lexer, out *struct {
+	Name string `json:"name"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	{
-		const prefix string = ",\"mimeType\":"
-		out.RawString(prefix)
-		out.String(string(in.MimeType))
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
-	{
-		const prefix string = ",\"content\":"
-		out.RawString(prefix)
-		out.String(string(in.Content))
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjson2a877177Encode11(out *jwriter.Writer, in struct {
+	Name string `json:"name"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"thumbnail\":"
-		out.RawString(prefix)
-		out.String(string(in.Thumbnail))
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode15(in *jlexer.Lexer, out *struct {
-	Key       string `json:"key"`
-	AccountID string `json:"accountId"`
+func easyjson2a877177Decode10(in *jlexer.Lexer, out *struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -4043,7 +4567,7 @@ func easyjson2a877177Decode15(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4051,10 +4575,10 @@ func easyjson2a877177Decode15(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "key":
-			out.Key = string(in.String())
-		case "accountId":
-			out.AccountID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "id":
+			out.ID = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4065,32 +4589,30 @@ func easyjson2a877177Decode15(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode15(out *jwriter.Writer, in struct {
-	Key       string `json:"key"`
-	AccountID string `json:"accountId"`
+func easyjson2a877177Encode10(out *jwriter.Writer, in struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
 }) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"key\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Key))
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"accountId\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix)
-		out.String(string(in.AccountID))
+		out.String(string(in.ID))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode13(in *jlexer.Lexer, out *struct {
-	ID   string `json:"id"`
-	Type struct {
-		Name string `json:"name"`
-	} `json:"type"`
-	OutwardIssue linkedIssue `json:"outwardIssue"`
-	InwardIssue  linkedIssue `json:"inwardIssue"`
+func easyjson2a877177Decode9(in *jlexer.Lexer, out *struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Subtask        bool   `json:"subtask"`
+	HierarchyLevel int    `json:"hierarchyLevel"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -4102,7 +4624,7 @@ func easyjson2a877177Decode13(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4112,12 +4634,12 @@ func easyjson2a877177Decode13(in *jlexer.Lexer, out *struct {
 		switch key {
 		case "id":
 			out.ID = string(in.String())
-		case "type":
-			easyjson2a877177Decode12(in, &out.Type)
-		case "outwardIssue":
-			(out.OutwardIssue).UnmarshalEasyJSON(in)
-		case "inwardIssue":
-			(out.InwardIssue).UnmarshalEasyJSON(in)
+		case "name":
+			out.Name = string(in.String())
+		case "subtask":
+			out.Subtask = bool(in.Bool())
+		case "hierarchyLevel":
+			out.HierarchyLevel = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -4128,13 +4650,11 @@ func easyjson2a877177Decode13(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode13(out *jwriter.Writer, in struct {
-	ID   string `json:"id"`
-	Type struct {
-		Name string `json:"name"`
-	} `json:"type"`
-	OutwardIssue linkedIssue `json:"outwardIssue"`
-	InwardIssue  linkedIssue `json:"inwardIssue"`
+func easyjson2a877177Encode9(out *jwriter.Writer, in struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Subtask        bool   `json:"subtask"`
+	HierarchyLevel int    `json:"hierarchyLevel"`
 }) {
 	out.RawByte('{')
 	first := true
@@ -4145,23 +4665,24 @@ func easyjson2a877177Encode13(out *jwriter.Writer, in struct {
 		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode12(out, in.Type)
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"outwardIssue\":"
+		const prefix string = ",\"subtask\":"
 		out.RawString(prefix)
-		(in.OutwardIssue).MarshalEasyJSON(out)
+		out.Bool(bool(in.Subtask))
 	}
 	{
-		const prefix string = ",\"inwardIssue\":"
+		const prefix string = ",\"hierarchyLevel\":"
 		out.RawString(prefix)
-		(in.InwardIssue).MarshalEasyJSON(out)
+		out.Int(int(in.HierarchyLevel))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode12(in *jlexer.Lexer, out *struct {
+func easyjson2a877177Decode8(in *jlexer.Lexer, out *struct {
+	ID   string `json:"id"`
 	Name string `json:"name"`
 }) {
 	isTopLevel := in.IsStart()
@@ -4174,7 +4695,7 @@ func easyjson2a877177Decode12(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4182,6 +4703,8 @@ func easyjson2a877177Decode12(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
+		case "id":
+			out.ID = string(in.String())
 		case "name":
 			out.Name = string(in.String())
 		default:
@@ -4194,22 +4717,28 @@ func easyjson2a877177Decode12(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode12(out *jwriter.Writer, in struct {
+func easyjson2a877177Encode8(out *jwriter.Writer, in struct {
+	ID   string `json:"id"`
 	Name string `json:"name"`
 }) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
 		out.String(string(in.Name))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode11(in *jlexer.Lexer, out *struct {
-	Name string `json:"name"`
-	ID   string `json:"id"`
+func easyjson2a877177Decode7(in *jlexer.Lexer, out *struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -4221,7 +4750,7 @@ func easyjson2a877177Decode11(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4229,10 +4758,10 @@ func easyjson2a877177Decode11(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "name":
-			out.Name = string(in.String())
 		case "id":
 			out.ID = string(in.String())
+		case "key":
+			out.Key = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4243,29 +4772,26 @@ func easyjson2a877177Decode11(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode11(out *jwriter.Writer, in struct {
-	Name string `json:"name"`
-	ID   string `json:"id"`
+func easyjson2a877177Encode7(out *jwriter.Writer, in struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
 }) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"key\":"
 		out.RawString(prefix)
-		out.String(string(in.ID))
+		out.String(string(in.Key))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode10(in *jlexer.Lexer, out *struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal34(in *jlexer.Lexer, out *issueCreateMeta) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4276,7 +4802,7 @@ func easyjson2a877177Decode10(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4284,10 +4810,29 @@ func easyjson2a877177Decode10(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "name":
-			out.Name = string(in.String())
+		case "projects":
+			if in.IsNull() {
+				in.Skip()
+				out.Projects = nil
+			} else {
+				in.Delim('[')
+				if out.Projects == nil {
+					if !in.IsDelim(']') {
+						out.Projects = make([]projectIssueCreateMeta, 0, 0)
+					} else {
+						out.Projects = []projectIssueCreateMeta{}
+					}
+				} else {
+					out.Projects = (out.Projects)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v60 projectIssueCreateMeta
+					(v60).UnmarshalEasyJSON(in)
+					out.Projects = append(out.Projects, v60)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -4298,26 +4843,745 @@ func easyjson2a877177Decode10(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode10(out *jwriter.Writer, in struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal34(out *jwriter.Writer, in issueCreateMeta) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		const prefix string = ",\"projects\":"
+		out.RawString(prefix[1:])
+		if in.Projects == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v61, v62 := range in.Projects {
+				if v61 > 0 {
+					out.RawByte(',')
+				}
+				(v62).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v issueCreateMeta) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v issueCreateMeta) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *issueCreateMeta) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *issueCreateMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal34(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal35(in *jlexer.Lexer, out *idValue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal35(out *jwriter.Writer, in idValue) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v idValue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v idValue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *idValue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *idValue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal35(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal36(in *jlexer.Lexer, out *customFieldSchema) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "items":
+			out.Items = string(in.String())
+		case "custom":
+			out.Custom = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal36(out *jwriter.Writer, in customFieldSchema) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		out.String(string(in.Items))
+	}
+	{
+		const prefix string = ",\"custom\":"
+		out.RawString(prefix)
+		out.String(string(in.Custom))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v customFieldSchema) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v customFieldSchema) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *customFieldSchema) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *customFieldSchema) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal36(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal37(in *jlexer.Lexer, out *customFieldQueryResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "key":
+			out.Key = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "schema":
+			(out.Schema).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal37(out *jwriter.Writer, in customFieldQueryResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix)
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"schema\":"
+		out.RawString(prefix)
+		(in.Schema).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v customFieldQueryResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v customFieldQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *customFieldQueryResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *customFieldQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal37(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal38(in *jlexer.Lexer, out *createMetaIssueTypes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "iconUrl":
+			out.IconURL = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "untranslatedName":
+			out.UntranslatedName = string(in.String())
+		case "subtask":
+			out.Subtask = bool(in.Bool())
+		case "expand":
+			out.Expand = string(in.String())
+		case "fields":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Fields = make(map[string]issueTypeField)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v63 issueTypeField
+					(v63).UnmarshalEasyJSON(in)
+					(out.Fields)[key] = v63
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal38(out *jwriter.Writer, in createMetaIssueTypes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"iconUrl\":"
+		out.RawString(prefix)
+		out.String(string(in.IconURL))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"untranslatedName\":"
+		out.RawString(prefix)
+		out.String(string(in.UntranslatedName))
+	}
+	{
+		const prefix string = ",\"subtask\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Subtask))
+	}
+	{
+		const prefix string = ",\"expand\":"
+		out.RawString(prefix)
+		out.String(string(in.Expand))
+	}
+	{
+		const prefix string = ",\"fields\":"
+		out.RawString(prefix)
+		if in.Fields == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v64First := true
+			for v64Name, v64Value := range in.Fields {
+				if v64First {
+					v64First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v64Name))
+				out.RawByte(':')
+				(v64Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v createMetaIssueTypes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v createMetaIssueTypes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *createMetaIssueTypes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *createMetaIssueTypes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal38(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal39(in *jlexer.Lexer, out *commentVisibility) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "value":
+			out.Value = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal39(out *jwriter.Writer, in commentVisibility) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix)
+		out.String(string(in.Value))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v commentVisibility) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v commentVisibility) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *commentVisibility) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *commentVisibility) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal39(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal40(in *jlexer.Lexer, out *commentQueryResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "startAt":
+			out.StartAt = int(in.Int())
+		case "maxResults":
+			out.MaxResults = int(in.Int())
+		case "total":
+			out.Total = int(in.Int())
+		case "comments":
+			if in.IsNull() {
+				in.Skip()
+				out.Comments = nil
+			} else {
+				in.Delim('[')
+				if out.Comments == nil {
+					if !in.IsDelim(']') {
+						out.Comments = make([]comment, 0, 0)
+					} else {
+						out.Comments = []comment{}
+					}
+				} else {
+					out.Comments = (out.Comments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v65 comment
+					(v65).UnmarshalEasyJSON(in)
+					out.Comments = append(out.Comments, v65)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal40(out *jwriter.Writer, in commentQueryResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"startAt\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.StartAt))
+	}
+	{
+		const prefix string = ",\"maxResults\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxResults))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"comments\":"
+		out.RawString(prefix)
+		if in.Comments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v66, v67 := range in.Comments {
+				if v66 > 0 {
+					out.RawByte(',')
+				}
+				(v67).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v commentQueryResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v commentQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *commentQueryResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *commentQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal40(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal41(in *jlexer.Lexer, out *comment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "self":
+			out.Self = string(in.String())
+		case "id":
+			out.ID = string(in.String())
+		case "author":
+			(out.Author).UnmarshalEasyJSON(in)
+		case "body":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Body).UnmarshalJSON(data))
+			}
+		case "created":
+			out.Created = string(in.String())
+		case "updated":
+			out.Updated = string(in.String())
+		case "visibility":
+			if in.IsNull() {
+				in.Skip()
+				out.Visibility = nil
+			} else {
+				if out.Visibility == nil {
+					out.Visibility = new(commentVisibility)
+				}
+				(*out.Visibility).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal41(out *jwriter.Writer, in comment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"self\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Self))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		(in.Author).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.Raw((in.Body).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"updated\":"
+		out.RawString(prefix)
+		out.String(string(in.Updated))
 	}
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"visibility\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		if in.Visibility == nil {
+			out.RawString("null")
+		} else {
+			(*in.Visibility).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode9(in *jlexer.Lexer, out *struct{ Comments []comment }) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v comment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v comment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *comment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *comment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal41(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal42(in *jlexer.Lexer, out *changeLogQueryResult) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4328,7 +5592,7 @@ func easyjson2a877177Decode9(in *jlexer.Lexer, out *struct{ Comments []comment }
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4336,25 +5600,33 @@ func easyjson2a877177Decode9(in *jlexer.Lexer, out *struct{ Comments []comment }
 			continue
 		}
 		switch key {
-		case "comments":
+		case "startAt":
+			out.StartAt = int(in.Int())
+		case "maxResults":
+			out.MaxResults = int(in.Int())
+		case "total":
+			out.Total = int(in.Int())
+		case "isLast":
+			out.IsLast = bool(in.Bool())
+		case "values":
 			if in.IsNull() {
 				in.Skip()
-				out.Comments = nil
+				out.Values = nil
 			} else {
 				in.Delim('[')
-				if out.Comments == nil {
+				if out.Values == nil {
 					if !in.IsDelim(']') {
-						out.Comments = make([]comment, 0, 1)
+						out.Values = make([]changeLogHistory, 0, 0)
 					} else {
-						out.Comments = []comment{}
+						out.Values = []changeLogHistory{}
 					}
 				} else {
-					out.Comments = (out.Comments)[:0]
+					out.Values = (out.Values)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v52 comment
-					(v52).UnmarshalEasyJSON(in)
-					out.Comments = append(out.Comments, v52)
+					var v68 changeLogHistory
+					(v68).UnmarshalEasyJSON(in)
+					out.Values = append(out.Values, v68)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4369,32 +5641,73 @@ func easyjson2a877177Decode9(in *jlexer.Lexer, out *struct{ Comments []comment }
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode9(out *jwriter.Writer, in struct{ Comments []comment }) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal42(out *jwriter.Writer, in changeLogQueryResult) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"comments\":"
+		const prefix string = ",\"startAt\":"
 		out.RawString(prefix[1:])
-		if in.Comments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.Int(int(in.StartAt))
+	}
+	{
+		const prefix string = ",\"maxResults\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxResults))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	{
+		const prefix string = ",\"isLast\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsLast))
+	}
+	{
+		const prefix string = ",\"values\":"
+		out.RawString(prefix)
+		if in.Values == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v53, v54 := range in.Comments {
-				if v53 > 0 {
+			for v69, v70 := range in.Values {
+				if v69 > 0 {
 					out.RawByte(',')
 				}
-				(v54).MarshalEasyJSON(out)
+				(v70).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177Decode8(in *jlexer.Lexer, out *struct {
-	ID  string `json:"id"`
-	Key string `json:"key"`
-}) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v changeLogQueryResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2a877177EncodeGithubComPinptJiraInternal42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v changeLogQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *changeLogQueryResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2a877177DecodeGithubComPinptJiraInternal42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *changeLogQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal42(l, v)
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal43(in *jlexer.Lexer, out *changeLogItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4405,7 +5718,7 @@ func easyjson2a877177Decode8(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4413,10 +5726,20 @@ func easyjson2a877177Decode8(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = string(in.String())
-		case "key":
-			out.Key = string(in.String())
+		case "field":
+			out.Field = string(in.String())
+		case "fieldId":
+			out.FieldID = string(in.String())
+		case "fieldtype":
+			out.FieldType = string(in.String())
+		case "from":
+			out.From = string(in.String())
+		case "fromString":
+			out.FromString = string(in.String())
+		case "to":
+			out.To = string(in.String())
+		case "toString":
+			out.ToString = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4427,92 +5750,72 @@ func easyjson2a877177Decode8(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode8(out *jwriter.Writer, in struct {
-	ID  string `json:"id"`
-	Key string `json:"key"`
-}) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal43(out *jwriter.Writer, in changeLogItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"field\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		out.String(string(in.Field))
 	}
 	{
-		const prefix string = ",\"key\":"
+		const prefix string = ",\"fieldId\":"
 		out.RawString(prefix)
-		out.String(string(in.Key))
+		out.String(string(in.FieldID))
 	}
-	out.RawByte('}')
-}
-func easyjson2a877177DecodeGithubComPinptJiraInternal28(in *jlexer.Lexer, out *idValue) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
+	{
+		const prefix string = ",\"fieldtype\":"
+		out.RawString(prefix)
+		out.String(string(in.FieldType))
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeString()
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "id":
-			out.ID = string(in.String())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
 	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+	{
+		const prefix string = ",\"fromString\":"
+		out.RawString(prefix)
+		out.String(string(in.FromString))
 	}
-}
-func easyjson2a877177EncodeGithubComPinptJiraInternal28(out *jwriter.Writer, in idValue) {
-	out.RawByte('{')
-	first := true
-	_ = first
 	{
-		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.ID))
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	{
+		const prefix string = ",\"toString\":"
+		out.RawString(prefix)
+		out.String(string(in.ToString))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v idValue) MarshalJSON() ([]byte, error) {
+func (v changeLogItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal28(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal43(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v idValue) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal28(w, v)
+func (v changeLogItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal43(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *idValue) UnmarshalJSON(data []byte) error {
+func (v *changeLogItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal28(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal43(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *idValue) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal28(l, v)
+func (v *changeLogItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal43(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal29(in *jlexer.Lexer, out *customFieldQueryResult) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal44(in *jlexer.Lexer, out *changeLogHistory) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4523,7 +5826,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal29(in *jlexer.Lexer, out *c
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4533,10 +5836,33 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal29(in *jlexer.Lexer, out *c
 		switch key {
 		case "id":
 			out.ID = string(in.String())
-		case "key":
-			out.Key = string(in.String())
-		case "name":
-			out.Name = string(in.String())
+		case "author":
+			(out.Author).UnmarshalEasyJSON(in)
+		case "created":
+			out.Created = string(in.String())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]changeLogItem, 0, 0)
+					} else {
+						out.Items = []changeLogItem{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v71 changeLogItem
+					(v71).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v71)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -4547,7 +5873,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal29(in *jlexer.Lexer, out *c
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal29(out *jwriter.Writer, in customFieldQueryResult) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal44(out *jwriter.Writer, in changeLogHistory) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4557,42 +5883,58 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal29(out *jwriter.Writer, in
 		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"key\":"
+		const prefix string = ",\"author\":"
 		out.RawString(prefix)
-		out.String(string(in.Key))
+		(in.Author).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v72, v73 := range in.Items {
+				if v72 > 0 {
+					out.RawByte(',')
+				}
+				(v73).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v customFieldQueryResult) MarshalJSON() ([]byte, error) {
+func (v changeLogHistory) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal29(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal44(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v customFieldQueryResult) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal29(w, v)
+func (v changeLogHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal44(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *customFieldQueryResult) UnmarshalJSON(data []byte) error {
+func (v *changeLogHistory) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal29(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal44(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *customFieldQueryResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal29(l, v)
+func (v *changeLogHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal44(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal30(in *jlexer.Lexer, out *comment) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal45(in *jlexer.Lexer, out *boardSource) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4603,28 +5945,24 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal30(in *jlexer.Lexer, out *c
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "self":
-			out.Self = string(in.String())
-		case "id":
-			out.ID = string(in.String())
-		case "author":
-			(out.Author).UnmarshalEasyJSON(in)
-		case "body":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Body).UnmarshalJSON(data))
-			}
-		case "created":
-			out.Created = string(in.String())
-		case "updated":
-			out.Updated = string(in.String())
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "self":
+			out.Self = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "location":
+			easyjson2a877177Decode13(in, &out.Location)
 		default:
 			in.SkipRecursive()
 		}
@@ -4635,67 +5973,65 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal30(in *jlexer.Lexer, out *c
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal30(out *jwriter.Writer, in comment) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal45(out *jwriter.Writer, in boardSource) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"self\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Self))
-	}
 	{
 		const prefix string = ",\"id\":"
-		out.RawString(prefix)
-		out.String(string(in.ID))
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
 	}
 	{
-		const prefix string = ",\"author\":"
+		const prefix string = ",\"self\":"
 		out.RawString(prefix)
-		(in.Author).MarshalEasyJSON(out)
+		out.String(string(in.Self))
 	}
 	{
-		const prefix string = ",\"body\":"
+		const prefix string = ",\"name\":"
 		out.RawString(prefix)
-		out.Raw((in.Body).MarshalJSON())
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"created\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		out.String(string(in.Created))
+		out.String(string(in.Type))
 	}
 	{
-		const prefix string = ",\"updated\":"
+		const prefix string = ",\"location\":"
 		out.RawString(prefix)
-		out.String(string(in.Updated))
+		easyjson2a877177Encode13(out, in.Location)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v comment) MarshalJSON() ([]byte, error) {
+func (v boardSource) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal30(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal45(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v comment) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal30(w, v)
+func (v boardSource) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal45(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *comment) UnmarshalJSON(data []byte) error {
+func (v *boardSource) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal30(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal45(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *comment) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal30(l, v)
+func (v *boardSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal45(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal31(in *jlexer.Lexer, out *changeLogItem) {
+func easyjson2a877177Decode13(in *jlexer.Lexer, out *struct {
+	ID         int    `json:"projectId"`
+	ProjectKey string `json:"projectKey"`
+}) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4706,7 +6042,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal31(in *jlexer.Lexer, out *c
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4714,18 +6050,10 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal31(in *jlexer.Lexer, out *c
 			continue
 		}
 		switch key {
-		case "field":
-			out.Field = string(in.String())
-		case "fieldtype":
-			out.FieldType = string(in.String())
-		case "from":
-			out.From = string(in.String())
-		case "fromString":
-			out.FromString = string(in.String())
-		case "to":
-			out.To = string(in.String())
-		case "toString":
-			out.ToString = string(in.String())
+		case "projectId":
+			out.ID = int(in.Int())
+		case "projectKey":
+			out.ProjectKey = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4736,67 +6064,92 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal31(in *jlexer.Lexer, out *c
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal31(out *jwriter.Writer, in changeLogItem) {
+func easyjson2a877177Encode13(out *jwriter.Writer, in struct {
+	ID         int    `json:"projectId"`
+	ProjectKey string `json:"projectKey"`
+}) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"field\":"
+		const prefix string = ",\"projectId\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Field))
+		out.Int(int(in.ID))
 	}
 	{
-		const prefix string = ",\"fieldtype\":"
+		const prefix string = ",\"projectKey\":"
 		out.RawString(prefix)
-		out.String(string(in.FieldType))
+		out.String(string(in.ProjectKey))
 	}
-	{
-		const prefix string = ",\"from\":"
-		out.RawString(prefix)
-		out.String(string(in.From))
+	out.RawByte('}')
+}
+func easyjson2a877177DecodeGithubComPinptJiraInternal46(in *jlexer.Lexer, out *boardIssueRes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	{
-		const prefix string = ",\"fromString\":"
-		out.RawString(prefix)
-		out.String(string(in.FromString))
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
-	{
-		const prefix string = ",\"to\":"
-		out.RawString(prefix)
-		out.String(string(in.To))
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjson2a877177EncodeGithubComPinptJiraInternal46(out *jwriter.Writer, in boardIssueRes) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"toString\":"
-		out.RawString(prefix)
-		out.String(string(in.ToString))
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Total))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v changeLogItem) MarshalJSON() ([]byte, error) {
+func (v boardIssueRes) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal31(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal46(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v changeLogItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal31(w, v)
+func (v boardIssueRes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal46(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *changeLogItem) UnmarshalJSON(data []byte) error {
+func (v *boardIssueRes) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal31(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal46(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *changeLogItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal31(l, v)
+func (v *boardIssueRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal46(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal32(in *jlexer.Lexer, out *boardSource) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal47(in *jlexer.Lexer, out *attachment) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4807,7 +6160,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal32(in *jlexer.Lexer, out *b
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4816,15 +6169,21 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal32(in *jlexer.Lexer, out *b
 		}
 		switch key {
 		case "id":
-			out.ID = int(in.Int())
-		case "self":
-			out.Self = string(in.String())
-		case "name":
-			out.Name = string(in.String())
-		case "type":
-			out.Type = string(in.String())
-		case "location":
-			easyjson2a877177Decode16(in, &out.Location)
+			out.ID = string(in.String())
+		case "filename":
+			out.Filename = string(in.String())
+		case "author":
+			easyjson2a877177Decode14(in, &out.Author)
+		case "created":
+			out.Created = string(in.String())
+		case "size":
+			out.Size = int(in.Int())
+		case "mimeType":
+			out.MimeType = string(in.String())
+		case "content":
+			out.Content = string(in.String())
+		case "thumbnail":
+			out.Thumbnail = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4835,64 +6194,79 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal32(in *jlexer.Lexer, out *b
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal32(out *jwriter.Writer, in boardSource) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal47(out *jwriter.Writer, in attachment) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
+		out.String(string(in.ID))
 	}
 	{
-		const prefix string = ",\"self\":"
+		const prefix string = ",\"filename\":"
 		out.RawString(prefix)
-		out.String(string(in.Self))
+		out.String(string(in.Filename))
 	}
 	{
-		const prefix string = ",\"name\":"
+		const prefix string = ",\"author\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		easyjson2a877177Encode14(out, in.Author)
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.String(string(in.Created))
 	}
 	{
-		const prefix string = ",\"location\":"
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int(int(in.Size))
+	}
+	{
+		const prefix string = ",\"mimeType\":"
+		out.RawString(prefix)
+		out.String(string(in.MimeType))
+	}
+	{
+		const prefix string = ",\"content\":"
+		out.RawString(prefix)
+		out.String(string(in.Content))
+	}
+	{
+		const prefix string = ",\"thumbnail\":"
 		out.RawString(prefix)
-		easyjson2a877177Encode16(out, in.Location)
+		out.String(string(in.Thumbnail))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v boardSource) MarshalJSON() ([]byte, error) {
+func (v attachment) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal32(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal47(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v boardSource) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal32(w, v)
+func (v attachment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal47(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *boardSource) UnmarshalJSON(data []byte) error {
+func (v *attachment) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal32(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal47(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *boardSource) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal32(l, v)
+func (v *attachment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal47(l, v)
 }
-func easyjson2a877177Decode16(in *jlexer.Lexer, out *struct {
-	ID         int    `json:"projectId"`
-	ProjectKey string `json:"projectKey"`
+func easyjson2a877177Decode14(in *jlexer.Lexer, out *struct {
+	Key       string `json:"key"`
+	AccountID string `json:"accountId"`
 }) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -4904,7 +6278,7 @@ func easyjson2a877177Decode16(in *jlexer.Lexer, out *struct {
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4912,10 +6286,10 @@ func easyjson2a877177Decode16(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "projectId":
-			out.ID = int(in.Int())
-		case "projectKey":
-			out.ProjectKey = string(in.String())
+		case "key":
+			out.Key = string(in.String())
+		case "accountId":
+			out.AccountID = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4926,26 +6300,26 @@ func easyjson2a877177Decode16(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson2a877177Encode16(out *jwriter.Writer, in struct {
-	ID         int    `json:"projectId"`
-	ProjectKey string `json:"projectKey"`
+func easyjson2a877177Encode14(out *jwriter.Writer, in struct {
+	Key       string `json:"key"`
+	AccountID string `json:"accountId"`
 }) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"projectId\":"
+		const prefix string = ",\"key\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
+		out.String(string(in.Key))
 	}
 	{
-		const prefix string = ",\"projectKey\":"
+		const prefix string = ",\"accountId\":"
 		out.RawString(prefix)
-		out.String(string(in.ProjectKey))
+		out.String(string(in.AccountID))
 	}
 	out.RawByte('}')
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal33(in *jlexer.Lexer, out *boardIssueRes) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal48(in *jlexer.Lexer, out *allowedValueComponent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4956,7 +6330,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal33(in *jlexer.Lexer, out *b
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -4964,8 +6338,10 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal33(in *jlexer.Lexer, out *b
 			continue
 		}
 		switch key {
-		case "total":
-			out.Total = int(in.Int())
+		case "id":
+			out.RefID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4976,42 +6352,47 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal33(in *jlexer.Lexer, out *b
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal33(out *jwriter.Writer, in boardIssueRes) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal48(out *jwriter.Writer, in allowedValueComponent) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"total\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Total))
+		out.String(string(in.RefID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v boardIssueRes) MarshalJSON() ([]byte, error) {
+func (v allowedValueComponent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal33(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v boardIssueRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal33(w, v)
+func (v allowedValueComponent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2a877177EncodeGithubComPinptJiraInternal48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *boardIssueRes) UnmarshalJSON(data []byte) error {
+func (v *allowedValueComponent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal33(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *boardIssueRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal33(l, v)
+func (v *allowedValueComponent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2a877177DecodeGithubComPinptJiraInternal48(l, v)
 }
-func easyjson2a877177DecodeGithubComPinptJiraInternal34(in *jlexer.Lexer, out *Avatars) {
+func easyjson2a877177DecodeGithubComPinptJiraInternal49(in *jlexer.Lexer, out *Avatars) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5022,7 +6403,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal34(in *jlexer.Lexer, out *A
 	}
 	in.Delim('{')
 	for !in.IsDelim('}') {
-		key := in.UnsafeString()
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -5048,7 +6429,7 @@ func easyjson2a877177DecodeGithubComPinptJiraInternal34(in *jlexer.Lexer, out *A
 		in.Consumed()
 	}
 }
-func easyjson2a877177EncodeGithubComPinptJiraInternal34(out *jwriter.Writer, in Avatars) {
+func easyjson2a877177EncodeGithubComPinptJiraInternal49(out *jwriter.Writer, in Avatars) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5078,23 +6459,23 @@ func easyjson2a877177EncodeGithubComPinptJiraInternal34(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Avatars) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2a877177EncodeGithubComPinptJiraInternal34(&w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Avatars) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2a877177EncodeGithubComPinptJiraInternal34(w, v)
+	easyjson2a877177EncodeGithubComPinptJiraInternal49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Avatars) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2a877177DecodeGithubComPinptJiraInternal34(&r, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Avatars) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2a877177DecodeGithubComPinptJiraInternal34(l, v)
+	easyjson2a877177DecodeGithubComPinptJiraInternal49(l, v)
 }
@@ -7,22 +7,90 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/pinpt/adf"
 	"github.com/pinpt/agent/v4/sdk"
 )
 
 // easyjson:skip
 type customFieldIDs struct {
-	StoryPoints string
-	Epic        string
-	EpicName    string
-	StartDate   string
-	EndDate     string
-	Sprint      string
+	StoryPoints        string
+	StoryPointEstimate string // next-gen projects use this instead of story points
+	Epic               string
+	EpicName           string
+	StartDate          string
+	EndDate            string
+	Sprint             string
+	Rank               string
+}
+
+// the schema types of the custom fields we know about, which unlike their names aren't translated
+const (
+	schemaCustomSprint             = "com.pyxis.greenhopper.jira:gh-sprint"
+	schemaCustomEpicLink           = "com.pyxis.greenhopper.jira:gh-epic-link"
+	schemaCustomEpicName           = "com.pyxis.greenhopper.jira:gh-epic-label"
+	schemaCustomStoryPointEstimate = "com.pyxis.greenhopper.jira:jsw-story-points"
+	schemaCustomRank               = "com.pyxis.greenhopper.jira:gh-lexo-rank"
+	schemaCustomStartDate          = "com.atlassian.jpo:jpo-custom-field-baseline-start"
+	schemaCustomEndDate            = "com.atlassian.jpo:jpo-custom-field-baseline-end"
+)
+
+// findCustomFieldIDs returns the ids of the custom fields we know about. they are found by their schema type, and by
+// their english name only if there isn't a field with the type
+func findCustomFieldIDs(fields map[string]customField) customFieldIDs {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	// use the oldest field if there's more than one so it's always the same one
+	sort.Strings(keys)
+	var ids customFieldIDs
+	known := []struct {
+		custom string
+		name   string
+		id     *string
+	}{
+		{"", "Story Points", &ids.StoryPoints}, // a plain number field so it only has the name
+		{schemaCustomStoryPointEstimate, "Story point estimate", &ids.StoryPointEstimate},
+		{schemaCustomEpicLink, "Epic Link", &ids.Epic},
+		{schemaCustomEpicName, "Epic Name", &ids.EpicName},
+		{schemaCustomStartDate, "Start Date", &ids.StartDate},
+		{schemaCustomEndDate, "End Date", &ids.EndDate},
+		{schemaCustomSprint, "Sprint", &ids.Sprint},
+		{schemaCustomRank, "Rank", &ids.Rank},
+	}
+	for _, k := range known {
+		if k.custom != "" {
+			for _, key := range keys {
+				if fields[key].Custom == k.custom {
+					*k.id = key
+					break
+				}
+			}
+		}
+		if *k.id == "" {
+			for _, key := range keys {
+				if fields[key].Name == k.name {
+					*k.id = key
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// isStoryPointsChange returns true if a changelog item changed the Story Points of a company-managed project or the
+// Story point estimate of a team-managed one. their names are translated so they're matched by id
+func isStoryPointsChange(item changeLogItem, ids customFieldIDs) bool {
+	if item.FieldType != "custom" || item.FieldID == "" {
+		return false
+	}
+	return item.FieldID == ids.StoryPoints || item.FieldID == ids.StoryPointEstimate
 }
 
 // easyjson:skip
@@ -36,6 +104,13 @@ type customFieldValue struct {
 type customField struct {
 	ID   string
 	Name string
+	// Type and Items are the types of the field's value, and of the values in it when it's an array
+	Type  string
+	Items string
+	// Custom is the schema type of a custom field, such as com.pyxis.greenhopper.jira:gh-sprint
+	Custom string
+	// Mapping is set when the instance has configured where the field's value goes
+	Mapping *customFieldMapping
 }
 
 func extractSprints(fields map[string]interface{}, ids customFieldIDs) ([]sprint, bool, error) {
@@ -55,7 +130,9 @@ func extractSprints(fields map[string]interface{}, ids customFieldIDs) ([]sprint
 	return nil, false, nil
 }
 
-func createChangeLog(customerID string, refID string, userRefID string, createdAt time.Time, ordinal int64, item changeLogItem) *sdk.WorkIssueChangeLog {
+// createChangeLog returns the change for a changelog item, or nil if we don't track changes to the field. fields are
+// the custom fields, for the changes to those which are mapped, and ids are the ones we know about
+func createChangeLog(customerID string, refID string, userRefID string, createdAt time.Time, ordinal int64, item changeLogItem, fields map[string]customField, ids customFieldIDs) *sdk.WorkIssueChangeLog {
 	change := sdk.WorkIssueChangeLog{
 		RefID:   refID,
 		UserID:  userRefID,
@@ -68,7 +145,19 @@ func createChangeLog(customerID string, refID string, userRefID string, createdA
 	change.FromString = item.FromString + " @ " + item.From
 	change.ToString = item.ToString + " @ " + item.To
 
-	switch strings.ToLower(item.Field) {
+	field := strings.ToLower(item.Field)
+	if item.FieldType == "custom" && item.FieldID != "" {
+		// the names of custom fields are translated so they're matched by id
+		switch item.FieldID {
+		case ids.Sprint:
+			field = "sprint"
+		case ids.Epic:
+			field = "epic link"
+		default:
+			field = item.FieldID
+		}
+	}
+	switch field {
 	case "status":
 		change.Field = sdk.WorkIssueChangeLogFieldStatus
 		change.From = item.FromString
@@ -136,7 +225,8 @@ func createChangeLog(customerID string, refID string, userRefID string, createdA
 		}
 		change.From = strings.Join(from, ",")
 		change.To = strings.Join(to, ",")
-	case "parent":
+	case "parent", "issueparentassociation":
+		// team-managed projects call the parent IssueParentAssociation, it's also how they change the epic
 		change.Field = sdk.WorkIssueChangeLogFieldParentID
 		if item.From != "" {
 			change.From = sdk.NewWorkIssueID(customerID, item.From, refType)
@@ -153,7 +243,10 @@ func createChangeLog(customerID string, refID string, userRefID string, createdA
 			change.To = sdk.NewWorkIssueID(customerID, item.To, refType)
 		}
 	default:
-		return nil
+		field, ok := mappedChangeLogField(fields, item)
+		if !ok || !setMappedChangeLog(&change, field, item) {
+			return nil
+		}
 	}
 	return &change
 }
@@ -177,12 +270,24 @@ func makeTransitions(currentStatus string, raw []transitionSource) []sdk.WorkIss
 	return transitions
 }
 
-// ToModel will convert a issueSource (from Jira) to a sdk.WorkIssue object
-func (i issueSource) ToModel(customerID string, integrationInstanceID string, issueManager *issueIDManager, sprintManager *sprintManager, userManager UserManager, fieldByID map[string]customField, websiteURL string, fetchTransitive bool) (*sdk.WorkIssue, []*sdk.WorkIssueComment, error) {
+// projectID returns the jira id of the project the issue is in
+func (i issueSource) projectID() string {
+	project, _ := i.Fields["project"].(map[string]interface{})
+	id, _ := project["id"].(string)
+	return id
+}
+
+// ToModel will convert a issueSource (from Jira) to a sdk.WorkIssue object and its comments with the restriction policy
+// applied. the issue is nil if the policy skips it
+func (i issueSource) ToModel(customerID string, integrationInstanceID string, issueManager *issueIDManager, sprintManager *sprintManager, userManager UserManager, fieldByID map[string]customField, customFieldIDs customFieldIDs, websiteURL string, restriction restrictionPolicy, fetchTransitive bool) (*sdk.WorkIssue, []*sdk.WorkIssueComment, error) {
 	var fields issueFields
 	if err := sdk.MapToStruct(i.Fields, &fields); err != nil {
 		return nil, nil, err
 	}
+	if fields.Security != nil && restriction == restrictionPolicySkip {
+		return nil, nil, nil
+	}
+	redact := fields.Security != nil && restriction == restrictionPolicyRedact
 
 	// map of issue keys that this issue is dependent on
 	transitiveIssueKeys := make(map[string]bool)
@@ -208,8 +313,8 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 
 	issue.Title = fields.Summary
 
-	if fields.Description != nil {
-		html, err := adf.GenerateHTMLFromADF(fields.Description)
+	if fields.Description != nil && !redact {
+		html, err := contentToHTML(fields.Description)
 		if err != nil {
 			return nil, nil, fmt.Errorf("could not parse description for jira issue: %v err: %v", i.Key, err)
 		}
@@ -218,11 +323,16 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 
 	comments := make([]*sdk.WorkIssueComment, 0)
 
+	// issue.ProjectID is never set so use the project from ProjectIds
+	projectID := issue.ProjectIds[0]
 	for _, comment := range fields.Comment.Comments {
-		thecomment, err := comment.ToModel(customerID, integrationInstanceID, websiteURL, userManager, *issue.ProjectID, issue.ID, i.Key)
+		thecomment, err := comment.ToModel(customerID, integrationInstanceID, websiteURL, userManager, projectID, issue.ID, i.Key, restriction, fields.Security)
 		if err != nil {
 			return nil, nil, fmt.Errorf("could create issue comment for jira issue: %v err: %v", i.Key, err)
 		}
+		if thecomment == nil {
+			continue
+		}
 		comments = append(comments, thecomment)
 	}
 
@@ -246,7 +356,13 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 	issue.Resolution = fields.Resolution.Name
 
 	if fields.Parent != nil && fields.Parent.ID != "" {
-		issue.ParentID = sdk.NewWorkIssueID(customerID, fields.Parent.ID, refType)
+		if parentIsEpic(fields.IssueType.Subtask, fields.IssueType.HierarchyLevel) {
+			// the Epic Link custom field below takes over if this is a company-managed project on server
+			epicID := sdk.NewWorkIssueID(customerID, fields.Parent.ID, refType)
+			issue.EpicID = &epicID
+		} else {
+			issue.ParentID = sdk.NewWorkIssueID(customerID, fields.Parent.ID, refType)
+		}
 	}
 
 	if !fields.Creator.IsZero() {
@@ -270,6 +386,9 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 
 	issue.URL = issueURL(websiteURL, i.Key)
 	issue.Tags = fields.Labels
+	if fields.Security != nil && restriction == restrictionPolicyExport {
+		issue.Tags = append(issue.Tags, securityLevelTag(fields.Security))
+	}
 
 	for _, link := range fields.IssueLinks {
 		var linkType sdk.WorkIssueLinkedIssuesLinkType
@@ -310,46 +429,16 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 	}
 
 	for _, data := range fields.Attachment {
-		var attachment sdk.WorkIssueAttachments
-		attachment.RefID = data.ID
-		attachment.Name = data.Filename
-		attachment.URL = data.Content
-		attachment.ThumbnailURL = data.Thumbnail
-		attachment.MimeType = data.MimeType
-		attachment.Size = int64(data.Size)
-		user := data.Author.AccountID // cloud
-		if user == "" {
-			user = data.Author.Key // hosted
-		}
-		attachment.UserRefID = user
-		created, err := parseTime(data.Created)
+		attachment, err := data.ToModel()
 		if err != nil {
 			return nil, nil, err
 		}
-		sdk.ConvertTimeToDateModel(created, &attachment.CreatedDate)
-		issue.Attachments = append(issue.Attachments, attachment)
-	}
-
-	customFieldIDs := customFieldIDs{}
-
-	for key, val := range fieldByID {
-		switch val.Name {
-		case "Story Points":
-			customFieldIDs.StoryPoints = key
-		case "Sprint":
-			customFieldIDs.Sprint = key
-		case "Epic Link":
-			customFieldIDs.Epic = key
-		case "Epic Name":
-			customFieldIDs.EpicName = key
-		case "Start Date":
-			customFieldIDs.StartDate = key
-		case "End Date":
-			customFieldIDs.EndDate = key
-		}
+		issue.Attachments = append(issue.Attachments, *attachment)
 	}
 
 	var epicKey string
+	var storyPointEstimate *float64
+	mapped := make([]string, 0)
 
 	for k, d := range i.Fields {
 		if !strings.HasPrefix(k, "customfield_") {
@@ -359,6 +448,10 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 		if !ok {
 			continue
 		}
+		if fd.Mapping != nil {
+			mapped = append(mapped, k)
+			continue
+		}
 		var v string
 		if d != nil {
 			if ds, ok := d.(string); ok {
@@ -393,6 +486,11 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 			if err == nil {
 				issue.StoryPoints = &sp
 			}
+		case customFieldIDs.StoryPointEstimate:
+			sp, err := strconv.ParseFloat(v, 32)
+			if err == nil {
+				storyPointEstimate = &sp
+			}
 		case customFieldIDs.Epic:
 			transitiveIssueKeys[v] = true
 			epicKey = v // will get set below
@@ -400,6 +498,25 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 			issue.EpicName = sdk.StringPointer(v)
 		}
 	}
+	// the estimate is only used when the issue doesn't have story points, whichever order the fields came in
+	if issue.StoryPoints == nil {
+		issue.StoryPoints = storyPointEstimate
+	}
+
+	// the fields the instance has mapped go after the ones we know by name so they take precedence
+	sort.Strings(mapped)
+	for _, k := range mapped {
+		fd := fieldByID[k]
+		typ, items := fd.valueTypes()
+		values, err := coerceCustomFieldValue(typ, items, i.Fields[k], userManager)
+		if err == nil {
+			err = applyCustomFieldMapping(issue, fd, values)
+		}
+		// like the fields above, a value we can't use is skipped instead of failing the issue
+		if err != nil && !errors.Is(err, errCustomFieldValue) {
+			return nil, nil, fmt.Errorf("error mapping custom field %s for jira issue: %v err: %w", fd.Name, i.Key, err)
+		}
+	}
 
 	sprints, foundSprintIDs, err := extractSprints(i.Fields, customFieldIDs)
 	if err != nil {
@@ -449,10 +566,13 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 			if err != nil {
 				return nil, nil, fmt.Errorf("could not parse created time of changelog for issue: %v err: %v", issue.RefID, err)
 			}
-			item := createChangeLog(customerID, cl.ID, cl.Author.RefID(), createdAt, ordinal, data)
+			item := createChangeLog(customerID, cl.ID, cl.Author.RefID(), createdAt, ordinal, data, fieldByID, customFieldIDs)
 			if item == nil {
 				continue
 			}
+			if item.Field == sdk.WorkIssueChangeLogFieldParentID && parentIsEpic(fields.IssueType.Subtask, fields.IssueType.HierarchyLevel) {
+				item.Field = sdk.WorkIssueChangeLogFieldEpicID
+			}
 			if item.Field == sdk.WorkIssueChangeLogFieldParentID || item.Field == sdk.WorkIssueChangeLogFieldEpicID {
 				transitiveIssueKeys[data.To] = true
 				transitiveIssueKeys[data.From] = true
@@ -518,23 +638,30 @@ func (i issueSource) ToModel(customerID string, integrationInstanceID string, is
 // easyjson:skip
 type issueIDManager struct {
 	refids        map[string]string
+	mu            sync.RWMutex
 	logger        sdk.Logger
 	i             *JiraIntegration
 	control       sdk.Control
 	pipe          sdk.Pipe
 	fields        map[string]customField
+	fieldIDs      customFieldIDs
 	sprintManager *sprintManager
 	userManager   UserManager
 	authConfig    authConfig
+	issueFilter   string
+	restriction   restrictionPolicy
 	stats         *stats
+	attachments   *attachmentMirror
 }
 
-func newIssueIDManager(logger sdk.Logger, i *JiraIntegration, control sdk.Control, pipe sdk.Pipe, sprintManager *sprintManager, userManager UserManager, fields map[string]customField, authConfig authConfig, stats *stats) *issueIDManager {
+func newIssueIDManager(logger sdk.Logger, i *JiraIntegration, control sdk.Control, pipe sdk.Pipe, sprintManager *sprintManager, userManager UserManager, fields map[string]customField, authConfig authConfig, issueFilter string, restriction restrictionPolicy, stats *stats) *issueIDManager {
 	return &issueIDManager{
 		refids:        make(map[string]string),
 		i:             i,
 		logger:        logger,
 		authConfig:    authConfig,
+		issueFilter:   issueFilter,
+		restriction:   restriction,
 		sprintManager: sprintManager,
 		userManager:   userManager,
 		control:       control,
@@ -545,11 +672,31 @@ func newIssueIDManager(logger sdk.Logger, i *JiraIntegration, control sdk.Contro
 }
 
 func (m *issueIDManager) cache(key string, refid string) {
+	m.mu.Lock()
 	m.refids[key] = refid
+	m.mu.Unlock()
+}
+
+func (m *issueIDManager) get(key string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.refids[key]
 }
 
 func (m *issueIDManager) isProcessed(key string) bool {
-	return m.refids[key] != ""
+	return m.get(key) != ""
+}
+
+// markProcessed will cache the key and refid and return true if the issue had not already been processed
+func (m *issueIDManager) markProcessed(key string, refid string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.refids[key] != "" {
+		return false
+	}
+	m.refids[key] = refid
+	m.refids[refid] = refid // do both since you can look it up by either
+	return true
 }
 
 func (m *issueIDManager) getRefIDsFromKeys(keys []string) ([]string, error) {
@@ -557,7 +704,7 @@ func (m *issueIDManager) getRefIDsFromKeys(keys []string) ([]string, error) {
 	foundkeys := make(map[string]bool)
 	notfound := make([]string, 0)
 	for _, key := range keys {
-		refid := m.refids[key]
+		refid := m.get(key)
 		if refid != "" {
 			found = append(found, refid)
 			foundkeys[key] = true
@@ -582,30 +729,40 @@ func (m *issueIDManager) getRefIDsFromKeys(keys []string) ([]string, error) {
 		return found, nil
 	}
 	// we have to go to Jira and fetch the keys we don't have locally
-	theurl := sdk.JoinURL(m.authConfig.APIURL, "/rest/api/3/search")
+	theurl := m.authConfig.restURL("/search")
 	sdk.LogDebug(m.logger, "fetching dependent issues", "notfound", notfound, "found", found)
 	qs := url.Values{}
-	qs.Set("jql", "key IN ("+strings.Join(notfound, ",")+")")
+	// don't pull in linked issues which the customer has excluded
+	qs.Set("jql", strings.TrimSpace(andIssueFilter("key IN ("+strings.Join(notfound, ",")+") ", m.issueFilter)))
 	setIssueExpand(qs)
-	qs.Set("fields", "*navigable,attachment")
+	qs.Set("fields", "*navigable,attachment,security")
 	var result issueQueryResult
 	client := m.i.httpmanager.New(theurl, nil)
 	for {
-		resp, err := client.Get(&result, append(m.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...)
-		if resp == nil && err != nil {
+		if _, err := client.Get(&result, append(m.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
 			return nil, err
 		}
 		for _, issue := range result.Issues {
 			// cache it before so we don't get into recursive loops
-			m.refids[issue.Key] = issue.ID
-			m.refids[issue.ID] = issue.ID
+			m.cache(issue.Key, issue.ID)
+			m.cache(issue.ID, issue.ID)
 		}
 		for _, issue := range result.Issues {
+			if err := m.i.fetchTruncatedIssueData(m.logger, m.control, m.authConfig, &issue); err != nil {
+				return nil, err
+			}
 			// recursively process it
-			issueObject, comments, err := issue.ToModel(m.control.CustomerID(), m.control.IntegrationInstanceID(), m, m.sprintManager, m.userManager, m.fields, m.authConfig.WebsiteURL, true)
+			issueObject, comments, err := m.toModel(issue, true)
 			if err != nil {
 				return nil, err
 			}
+			if issueObject == nil {
+				// it's restricted, so make sure it's removed if we had exported it
+				if err := m.pipe.Write(sdk.NewWorkIssueDeactivate(m.control.CustomerID(), m.control.IntegrationInstanceID(), issue.ID, refType)); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			if err := m.pipe.Write(issueObject); err != nil {
 				return nil, err
 			}
@@ -616,26 +773,24 @@ func (m *issueIDManager) getRefIDsFromKeys(keys []string) ([]string, error) {
 				m.stats.incComment()
 			}
 			m.stats.incIssue()
-			if rerr := m.i.checkForRateLimit(m.logger, m.control, m.control.CustomerID(), err, resp.Headers); rerr != nil {
-				return nil, rerr
-			}
 		}
 		res := make([]string, 0)
 		for _, key := range keys {
-			res = append(res, m.refids[key])
+			res = append(res, m.get(key))
 		}
 		// return in the order in which they came in
 		return res, nil
 	}
 }
 
-// fetch just one issue by refid, and optionally fetch any other transitive/mentioned issues
+// fetch just one issue by refid, and optionally fetch any other transitive/mentioned issues. the issue is nil if it's not
+// found or it's restricted and should be skipped
 func (m *issueIDManager) fetchIssue(refid string, fetchTransitive bool) (*sdk.WorkIssue, []*sdk.WorkIssueComment, error) {
-	theurl := sdk.JoinURL(m.authConfig.APIURL, "/rest/api/3/issue/", refid)
+	theurl := m.authConfig.restURL("/issue/", refid)
 	client := m.i.httpmanager.New(theurl, nil)
 	qs := url.Values{}
 	setIssueExpand(qs)
-	qs.Set("fields", "*navigable,attachment")
+	qs.Set("fields", "*navigable,attachment,security")
 	var issue issueSource
 	resp, err := client.Get(&issue, append(m.authConfig.Middleware, sdk.WithGetQueryParameters(qs))...)
 	if resp == nil && err != nil {
@@ -644,7 +799,28 @@ func (m *issueIDManager) fetchIssue(refid string, fetchTransitive bool) (*sdk.Wo
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, nil, nil
 	}
-	return issue.ToModel(m.control.CustomerID(), m.control.IntegrationInstanceID(), m, m.sprintManager, m.userManager, m.fields, m.authConfig.WebsiteURL, fetchTransitive)
+	if err := m.i.fetchTruncatedIssueData(m.logger, m.control, m.authConfig, &issue); err != nil {
+		return nil, nil, err
+	}
+	return m.toModel(issue, fetchTransitive)
+}
+
+// toModel converts the issue and mirrors its attachments, the issue is nil if it's restricted and should be skipped
+func (m *issueIDManager) toModel(issue issueSource, fetchTransitive bool) (*sdk.WorkIssue, []*sdk.WorkIssueComment, error) {
+	object, comments, err := issue.ToModel(m.control.CustomerID(), m.control.IntegrationInstanceID(), m, m.sprintManager, m.userManager, m.fields, m.fieldIDs, m.authConfig.WebsiteURL, m.restriction, fetchTransitive)
+	if err != nil || object == nil {
+		return object, comments, err
+	}
+	m.attachments.mirrorIssue(object, issue.redacted(m.restriction))
+	return object, comments, nil
+}
+
+// fetchTruncatedIssueData will fetch the changelogs and comments that jira doesn't include in full with an issue
+func (i *JiraIntegration) fetchTruncatedIssueData(logger sdk.Logger, control sdk.Control, authConfig authConfig, issue *issueSource) error {
+	if err := i.fetchIssueChangelogs(logger, control, authConfig, issue); err != nil {
+		return err
+	}
+	return i.fetchIssueComments(logger, control, authConfig, issue)
 }
 
 func setIssueExpand(qs url.Values) {
@@ -662,7 +838,28 @@ func getRefID(val sdk.MutationFieldValue) (string, error) {
 	return *nameID.RefID, nil
 }
 
-func makeCreateMutation(logger sdk.Logger, projectRefID string, fields []sdk.MutationFieldValue) (*mutationRequest, error) {
+// getRefIDs is for fields which can have more than one value, such as components
+func getRefIDs(val sdk.MutationFieldValue) ([]string, error) {
+	var nameIDs []sdk.NameRefID
+	if err := json.Unmarshal(val.Value, &nameIDs); err != nil {
+		// allow a single value as well
+		refID, err := getRefID(val)
+		if err != nil {
+			return nil, err
+		}
+		return []string{refID}, nil
+	}
+	refIDs := make([]string, 0)
+	for _, nameID := range nameIDs {
+		if nameID.RefID == nil {
+			return nil, errors.New("ref_id was omitted")
+		}
+		refIDs = append(refIDs, *nameID.RefID)
+	}
+	return refIDs, nil
+}
+
+func makeCreateMutation(logger sdk.Logger, authConfig authConfig, projectRefID string, fields []sdk.MutationFieldValue) (*mutationRequest, error) {
 	if projectRefID == "" {
 		return nil, errors.New("project ref id cannot be empty")
 	}
@@ -689,21 +886,7 @@ func makeCreateMutation(logger sdk.Logger, projectRefID string, fields []sdk.Mut
 			if err != nil {
 				return nil, fmt.Errorf("error decoding description field: %w", err)
 			}
-			createMutation.Fields["description"] = adf.Node{
-				Type:    "doc",
-				Version: 1,
-				Content: []adf.Node{
-					{
-						Type: "paragraph",
-						Content: []adf.Node{
-							{
-								Text: description,
-								Type: "text",
-							},
-						},
-					},
-				},
-			}
+			createMutation.Fields["description"] = contentValue(authConfig, description)
 		case "assignee":
 			assigneeRefID, err := getRefID(fieldVal)
 			if err != nil {
@@ -716,7 +899,28 @@ func makeCreateMutation(logger sdk.Logger, projectRefID string, fields []sdk.Mut
 				return nil, fmt.Errorf("error decoding priority refID: %w", err)
 			}
 			createMutation.Fields["priority"] = idValue{priorityRefID}
-			// TODO(robin): components, labels
+		case "components":
+			componentRefIDs, err := getRefIDs(fieldVal)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding components refIDs: %w", err)
+			}
+			components := make([]idValue, 0)
+			for _, refID := range componentRefIDs {
+				components = append(components, idValue{refID})
+			}
+			createMutation.Fields["components"] = components
+		case "parent":
+			// in a team-managed project this is the epic of the issue as well as the parent of a subtask
+			// the sdk won't decode a work issue as a NameRefID
+			var parent sdk.NameRefID
+			if err := json.Unmarshal(fieldVal.Value, &parent); err != nil {
+				return nil, fmt.Errorf("error decoding parent field: %w", err)
+			}
+			if parent.RefID == nil {
+				return nil, errors.New("parent ref_id was omitted")
+			}
+			createMutation.Fields["parent"] = idValue{*parent.RefID}
+			// TODO(robin): labels
 		default:
 			notFound = true
 		}
@@ -740,7 +944,7 @@ func makeCreateMutation(logger sdk.Logger, projectRefID string, fields []sdk.Mut
 				if err != nil {
 					return nil, fmt.Errorf("error decoding epic link: %w", err)
 				}
-				if nrid.Name != nil {
+				if nrid.Name == nil {
 					return nil, fmt.Errorf("linked epic was omitted")
 				}
 				createMutation.Fields[fieldVal.RefID] = *nrid.Name
@@ -756,7 +960,7 @@ func (i *JiraIntegration) createIssue(logger sdk.Logger, mutation sdk.Mutation,
 		return i.createIssueLegacy(logger, mutation, authConfig, event)
 	}
 	// only ProjectRefID and Fields will be available
-	createMutation, err := makeCreateMutation(logger, event.ProjectRefID, event.Fields)
+	createMutation, err := makeCreateMutation(logger, authConfig, event.ProjectRefID, event.Fields)
 	if err != nil {
 		return nil, err
 	}
@@ -782,21 +986,7 @@ func (i *JiraIntegration) createIssueLegacy(logger sdk.Logger, mutation sdk.Muta
 	createMutation.Fields["project"] = idValue{event.ProjectRefID}
 
 	if event.Description != "" {
-		createMutation.Fields["description"] = adf.Node{
-			Type:    "doc",
-			Version: 1,
-			Content: []adf.Node{
-				{
-					Type: "paragraph",
-					Content: []adf.Node{
-						{
-							Text: event.Description,
-							Type: "text",
-						},
-					},
-				},
-			},
-		}
+		createMutation.Fields["description"] = contentValue(authConfig, event.Description)
 	}
 
 	if event.AssigneeRefID != nil {
@@ -818,11 +1008,19 @@ func (i *JiraIntegration) createIssueLegacy(logger sdk.Logger, mutation sdk.Muta
 		if event.Epic.Name == nil {
 			return nil, errors.New("epic name cannot be empty")
 		}
-		epicFieldID, err := i.getEpicFieldID(logger, mutation, authConfig)
+		teamManaged, err := i.isTeamManagedProject(authConfig, event.ProjectRefID)
 		if err != nil {
 			return nil, err
 		}
-		createMutation.Fields[epicFieldID] = event.Epic.Name
+		if teamManaged {
+			createMutation.Fields["parent"] = keyValue{*event.Epic.Name}
+		} else {
+			epicFieldID, err := i.getEpicFieldID(logger, mutation, authConfig)
+			if err != nil {
+				return nil, err
+			}
+			createMutation.Fields[epicFieldID] = event.Epic.Name
+		}
 	}
 	if event.ParentRefID != nil {
 		createMutation.Fields["parent"] = idValue{*event.ParentRefID}
@@ -834,11 +1032,15 @@ func (i *JiraIntegration) createIssueLegacy(logger sdk.Logger, mutation sdk.Muta
 }
 
 func (i *JiraIntegration) execCreateMutation(logger sdk.Logger, customerID string, authConfig authConfig, createMutation mutationRequest) (*sdk.MutationResponse, error) {
-	theurl := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/issue")
+	theurl := authConfig.restURL("/issue")
 	client := i.httpmanager.New(theurl, nil)
 	resp, err := client.Post(sdk.StringifyReader(createMutation), nil, authConfig.Middleware...)
 	if err != nil {
-		sdk.LogError(logger, "error creating an issue", "err", err, "body", string(resp.Body), "request", sdk.Stringify(createMutation))
+		var body string
+		if resp != nil {
+			body = string(resp.Body)
+		}
+		sdk.LogError(logger, "error creating an issue", "err", err, "body", body, "request", sdk.Stringify(createMutation))
 		return nil, fmt.Errorf("mutation failed: %s", getJiraErrorMessage(err))
 	}
 	var respStruct struct {
@@ -866,7 +1068,7 @@ func (i *JiraIntegration) execCreateMutation(logger sdk.Logger, customerID strin
 				},
 			},
 		}
-		theurl = sdk.JoinURL(authConfig.APIURL, "/rest/api/3/issue/"+respStruct.Key+"/remotelink")
+		theurl = authConfig.restURL("/issue", respStruct.Key, "/remotelink")
 		client = i.httpmanager.New(theurl, nil)
 		if _, err := client.Post(sdk.StringifyReader(remoteLink), nil, authConfig.Middleware...); err != nil {
 			sdk.LogError(logger, "error creating remote link on create mutation", "err", getJiraErrorMessage(err))
@@ -891,17 +1093,44 @@ func (i *JiraIntegration) getEpicFieldID(logger sdk.Logger, mutation sdk.Mutatio
 		if err != nil {
 			return "", fmt.Errorf("error fetching custom fields for setting the epic id. %w", err)
 		}
-		for _, field := range customfields {
-			if field.Name == "Epic Link" {
-				epicFieldID = field.ID
-				mutation.State().Set(epicCustomFieldIDCacheKey, epicFieldID)
-				break
-			}
+		if epicFieldID = findCustomFieldIDs(customfields).Epic; epicFieldID != "" {
+			mutation.State().Set(epicCustomFieldIDCacheKey, epicFieldID)
 		}
 	}
 	return epicFieldID, nil
 }
 
+// isTeamManagedProject returns true if the project is team-managed, they link issues to their epic with the parent
+// field instead of the Epic Link custom field
+func (i *JiraIntegration) isTeamManagedProject(authConfig authConfig, projectRefID string) (bool, error) {
+	theurl := authConfig.restURL("/project/", projectRefID)
+	client := i.httpmanager.New(theurl, nil)
+	var p project
+	if _, err := client.Get(&p, authConfig.Middleware...); err != nil {
+		return false, fmt.Errorf("error fetching project %s: %w", projectRefID, err)
+	}
+	return p.isTeamManaged(), nil
+}
+
+// isTeamManagedIssue returns true if the issue is in a team-managed project
+func (i *JiraIntegration) isTeamManagedIssue(authConfig authConfig, issueRefID string) (bool, error) {
+	theurl := authConfig.restURL("/issue/", issueRefID)
+	client := i.httpmanager.New(theurl, nil)
+	qs := url.Values{}
+	qs.Set("fields", "project")
+	var resp struct {
+		Fields struct {
+			Project struct {
+				ID string `json:"id"`
+			} `json:"project"`
+		} `json:"fields"`
+	}
+	if _, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
+		return false, fmt.Errorf("error fetching project of issue %s: %w", issueRefID, err)
+	}
+	return i.isTeamManagedProject(authConfig, resp.Fields.Project.ID)
+}
+
 func (i *JiraIntegration) updateIssue(logger sdk.Logger, mutation sdk.Mutation, authConfig authConfig, event *sdk.WorkIssueUpdateMutation) (*sdk.MutationResponse, error) {
 	started := time.Now()
 	var hasMutation bool
@@ -943,28 +1172,49 @@ func (i *JiraIntegration) updateIssue(logger sdk.Logger, mutation sdk.Mutation,
 		hasMutation = true
 	}
 	if event.Set.Epic != nil || event.Unset.Epic {
-		epicFieldID, err := i.getEpicFieldID(logger, mutation, authConfig)
+		teamManaged, err := i.isTeamManagedIssue(authConfig, mutation.ID())
 		if err != nil {
 			return nil, err
 		}
-		if event.Unset.Epic {
-			updateMutation.Update[epicFieldID] = []setMutationOperation{
-				{
-					Set: nil,
-				},
+		if teamManaged {
+			// team-managed projects don't have the Epic Link field, the epic is the parent of the issue
+			if event.Unset.Epic {
+				updateMutation.Update["parent"] = []setMutationOperation{
+					{
+						Set: nil,
+					},
+				}
+			} else {
+				updateMutation.Update["parent"] = []setMutationOperation{
+					{
+						Set: keyValue{*event.Set.Epic.Name},
+					},
+				}
 			}
 		} else {
-			updateMutation.Update[epicFieldID] = []setMutationOperation{
-				{
-					Set: *event.Set.Epic.Name, // we use the name which should be set to the identifier in the case of an epic
-				},
+			epicFieldID, err := i.getEpicFieldID(logger, mutation, authConfig)
+			if err != nil {
+				return nil, err
+			}
+			if event.Unset.Epic {
+				updateMutation.Update[epicFieldID] = []setMutationOperation{
+					{
+						Set: nil,
+					},
+				}
+			} else {
+				updateMutation.Update[epicFieldID] = []setMutationOperation{
+					{
+						Set: *event.Set.Epic.Name, // we use the name which should be set to the identifier in the case of an epic
+					},
+				}
 			}
 		}
 		hasMutation = true
 	}
 	sdk.LogDebug(logger, "sending mutation", "payload", sdk.Stringify(updateMutation), "has_mutation", hasMutation)
 	if hasMutation {
-		theurl := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/issue/"+mutation.ID())
+		theurl := authConfig.restURL("/issue", mutation.ID())
 		client := i.httpmanager.New(theurl, nil)
 		if _, err := client.Put(sdk.StringifyReader(updateMutation), nil, authConfig.Middleware...); err != nil {
 			return nil, fmt.Errorf("mutation failed: %s", getJiraErrorMessage(err))
@@ -985,7 +1235,7 @@ func (i *JiraIntegration) updateIssue(logger sdk.Logger, mutation sdk.Mutation,
 			}
 		}
 		sdk.LogDebug(logger, "sending transition mutation", "payload", sdk.Stringify(updateMutation))
-		theurl := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/issue/"+mutation.ID()+"/transitions")
+		theurl := authConfig.restURL("/issue", mutation.ID(), "/transitions")
 		client := i.httpmanager.New(theurl, nil)
 		_, err := client.Post(sdk.StringifyReader(updateMutation), nil, authConfig.Middleware...)
 		if err != nil {
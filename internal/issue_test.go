@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -112,9 +114,235 @@ func TestCreateChangeLog(t *testing.T) {
 		To:         "6",
 		ToString:   "Closed",
 	}
-	c := createChangeLog("1234", "1", "robin", ts, 1, item)
+	c := createChangeLog("1234", "1", "robin", ts, 1, item, nil, customFieldIDs{})
 	assert.NotNil(c)
 	assert.Equal(sdk.WorkIssueChangeLogFieldStatus, c.Field)
 	assert.Equal("Closed", c.To)
 	assert.Equal("To Do", c.From)
 }
+
+func TestMakeCreateMutationComponents(t *testing.T) {
+	assert := assert.New(t)
+	fields := []sdk.MutationFieldValue{
+		{
+			RefID: "summary",
+			Type:  sdk.WorkProjectCapabilityIssueMutationFieldsTypeString,
+			Value: []byte(`"my issue"`),
+		},
+		{
+			RefID: "components",
+			Type:  sdk.WorkProjectCapabilityIssueMutationFieldsTypeStringArray,
+			Value: []byte(`[{"ref_id":"10000","name":"Backend"},{"ref_id":"10001","name":"Frontend"}]`),
+		},
+	}
+	mutation, err := makeCreateMutation(sdk.NewNoOpTestLogger(), authConfig{APIPath: apiPathV3}, "10000", fields)
+	assert.NoError(err)
+	assert.Equal([]idValue{{"10000"}, {"10001"}}, mutation.Fields["components"])
+
+	fields[1].Value = []byte(`{"ref_id":"10002","name":"Mobile"}`)
+	mutation, err = makeCreateMutation(sdk.NewNoOpTestLogger(), authConfig{APIPath: apiPathV3}, "10000", fields)
+	assert.NoError(err)
+	assert.Equal([]idValue{{"10002"}}, mutation.Fields["components"])
+}
+
+func TestFindCustomFieldIDs(t *testing.T) {
+	assert := assert.New(t)
+	fields := map[string]customField{
+		"customfield_10001": {ID: "customfield_10001", Name: "Sprint", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:textfield"},
+		"customfield_10002": {ID: "customfield_10002", Name: "Sprint", Custom: schemaCustomSprint},
+		"customfield_10003": {ID: "customfield_10003", Name: "Epic-Verknüpfung", Custom: schemaCustomEpicLink},
+		"customfield_10004": {ID: "customfield_10004", Name: "Schätzung der Story-Punkte", Custom: schemaCustomStoryPointEstimate},
+		"customfield_10005": {ID: "customfield_10005", Name: "Rang", Custom: schemaCustomRank},
+		"customfield_10006": {ID: "customfield_10006", Name: "Epic Name"},
+	}
+	assert.Equal(customFieldIDs{
+		StoryPointEstimate: "customfield_10004",
+		Epic:               "customfield_10003",
+		EpicName:           "customfield_10006", // by name since nothing has the schema type
+		Sprint:             "customfield_10002",
+		Rank:               "customfield_10005",
+	}, findCustomFieldIDs(fields))
+}
+
+func TestFindCustomFieldIDsFromBoards(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addBoard(1, 10000, "ABC")
+	jira.addBoard(2, 10001, "DEF")
+	jira.addBoard(3, 10002, "GHI")
+	// team-managed boards estimate with the Story point estimate, and boards can estimate with time instead
+	jira.estimationFields[1] = "customfield_10004"
+	jira.estimationFields[2] = "timeoriginalestimate"
+	jira.estimationFields[3] = "customfield_10007"
+	fields := map[string]customField{
+		"customfield_10004": {ID: "customfield_10004", Name: "Schätzung der Story-Punkte", Custom: schemaCustomStoryPointEstimate},
+		"customfield_10007": {ID: "customfield_10007", Name: "Story-Punkte", Custom: "com.atlassian.jira.plugin.system.customfieldtypes:float"},
+	}
+	i := newMockIntegration()
+	ids := i.findCustomFieldIDs(sdk.NewNoOpTestLogger(), "1234", "1", authConfig{APIURL: jira.URL(), SupportsAgileAPI: true}, fields)
+	assert.Equal("customfield_10007", ids.StoryPoints)
+	assert.Equal("customfield_10004", ids.StoryPointEstimate)
+
+	source := issueSource{
+		ID:  "10000",
+		Key: "ABC-1",
+		Fields: map[string]interface{}{
+			"project":           map[string]interface{}{"id": "1", "key": "ABC"},
+			"customfield_10007": 3.0,
+		},
+	}
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+	issue, _, err := source.ToModel("1234", "1", nil, sm, &mockUserManager{}, fields, customFieldIDs{StoryPoints: "customfield_10007"}, "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	assert.Equal(3.0, *issue.StoryPoints)
+}
+
+func TestCreateChangeLogMatchesCustomFieldsByID(t *testing.T) {
+	assert := assert.New(t)
+	ids := customFieldIDs{Sprint: "customfield_10020", Epic: "customfield_10014", StoryPoints: "customfield_10007"}
+	c := createChangeLog("1234", "1", "robin", time.Now(), 1, changeLogItem{Field: "Sprint", FieldID: "customfield_10020", FieldType: "custom", To: "5"}, nil, ids)
+	assert.NotNil(c)
+	assert.Equal(sdk.WorkIssueChangeLogFieldSprintIds, c.Field)
+	assert.Equal(sdk.NewAgileSprintID("1234", "5", refType), c.To)
+	c = createChangeLog("1234", "1", "robin", time.Now(), 1, changeLogItem{Field: "Epic-Verknüpfung", FieldID: "customfield_10014", FieldType: "custom", To: "10001"}, nil, ids)
+	assert.NotNil(c)
+	assert.Equal(sdk.WorkIssueChangeLogFieldEpicID, c.Field)
+	// a custom field which happens to be called Sprint isn't the sprints
+	assert.Nil(createChangeLog("1234", "1", "robin", time.Now(), 1, changeLogItem{Field: "Sprint", FieldID: "customfield_10001", FieldType: "custom", To: "5"}, nil, ids))
+
+	assert.True(isStoryPointsChange(changeLogItem{Field: "Story-Punkte", FieldID: "customfield_10007", FieldType: "custom"}, ids))
+	assert.False(isStoryPointsChange(changeLogItem{Field: "Story Points", FieldID: "customfield_10001", FieldType: "custom"}, ids))
+}
+
+func TestIssueToModelStoryPointEstimate(t *testing.T) {
+	assert := assert.New(t)
+	fields := map[string]customField{
+		"customfield_10004": {ID: "customfield_10004", Name: "Schätzung der Story-Punkte", Custom: schemaCustomStoryPointEstimate},
+	}
+	source := issueSource{
+		ID:  "10000",
+		Key: "ABC-1",
+		Fields: map[string]interface{}{
+			"project":           map[string]interface{}{"id": "1", "key": "ABC"},
+			"customfield_10004": 5.0,
+		},
+	}
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+	issue, _, err := source.ToModel("1234", "1", nil, sm, &mockUserManager{}, fields, findCustomFieldIDs(fields), "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	assert.Equal(5.0, *issue.StoryPoints)
+}
+
+func TestIssueToModelPrefersStoryPoints(t *testing.T) {
+	assert := assert.New(t)
+	fields := map[string]customField{
+		"customfield_10004": {ID: "customfield_10004", Name: "Story point estimate", Custom: schemaCustomStoryPointEstimate},
+		"customfield_10007": {ID: "customfield_10007", Name: "Story Points"},
+	}
+	ids := customFieldIDs{StoryPoints: "customfield_10007", StoryPointEstimate: "customfield_10004"}
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+	// the fields are a map so do it a few times to make sure the order they're seen in doesn't matter
+	for n := 0; n < 20; n++ {
+		source := issueSource{
+			ID:  "10000",
+			Key: "ABC-1",
+			Fields: map[string]interface{}{
+				"project":           map[string]interface{}{"id": "1", "key": "ABC"},
+				"customfield_10004": 5.0,
+				"customfield_10007": 3.0,
+			},
+		}
+		issue, _, err := source.ToModel("1234", "1", nil, sm, &mockUserManager{}, fields, ids, "https://example.atlassian.net", restrictionPolicyExport, false)
+		assert.NoError(err)
+		assert.Equal(3.0, *issue.StoryPoints)
+	}
+}
+
+func TestGetMappedIssueTypeHierarchyLevel(t *testing.T) {
+	assert := assert.New(t)
+	// team-managed projects can rename their types
+	assert.Equal(sdk.WorkIssueTypeMappedTypeEpic, getMappedIssueType("Initiative", false, hierarchyLevelEpic))
+	assert.Equal(sdk.WorkIssueTypeMappedTypeSubtask, getMappedIssueType("Checklist Item", false, hierarchyLevelSubtask))
+	assert.Equal(sdk.WorkIssueTypeMappedTypeEpic, getMappedIssueType("Epic", false, hierarchyLevelStandard))
+	assert.Equal(sdk.WorkIssueTypeMappedTypeUnknown, getMappedIssueType("Feature Request", false, hierarchyLevelStandard))
+}
+
+func TestIssueToModelTeamManagedParent(t *testing.T) {
+	assert := assert.New(t)
+	source := issueSource{
+		ID:  "10000",
+		Key: "ABC-1",
+		Fields: map[string]interface{}{
+			"project":   map[string]interface{}{"id": "1", "key": "ABC"},
+			"issuetype": map[string]interface{}{"id": "10001", "name": "Story", "subtask": false, "hierarchyLevel": 0},
+			"parent":    map[string]interface{}{"id": "10500", "key": "ABC-12"},
+		},
+	}
+	source.Changelog.Histories = []changeLogHistory{
+		{
+			ID:      "1",
+			Created: "2020-10-01T10:00:00.000+0000",
+			Items:   []changeLogItem{{Field: "IssueParentAssociation", FieldType: "jira", To: "10500", ToString: "ABC-12"}},
+		},
+	}
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+	issue, _, err := source.ToModel("1234", "1", nil, sm, &mockUserManager{}, nil, customFieldIDs{}, "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	// the parent of a standard issue is its epic
+	assert.Equal(sdk.NewWorkIssueID("1234", "10500", refType), *issue.EpicID)
+	assert.Empty(issue.ParentID)
+	assert.Len(issue.ChangeLog, 1)
+	assert.Equal(sdk.WorkIssueChangeLogFieldEpicID, issue.ChangeLog[0].Field)
+	assert.Equal(sdk.NewWorkIssueID("1234", "10500", refType), issue.ChangeLog[0].To)
+
+	// the parent of a subtask is its parent
+	source.Fields["issuetype"] = map[string]interface{}{"id": "10002", "name": "Subtask", "subtask": true, "hierarchyLevel": -1}
+	issue, _, err = source.ToModel("1234", "1", nil, sm, &mockUserManager{}, nil, customFieldIDs{}, "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	assert.Nil(issue.EpicID)
+	assert.Equal(sdk.NewWorkIssueID("1234", "10500", refType), issue.ParentID)
+	assert.Equal(sdk.WorkIssueChangeLogFieldParentID, issue.ChangeLog[0].Field)
+}
+
+func TestMakeCreateMutationParent(t *testing.T) {
+	assert := assert.New(t)
+	fields := []sdk.MutationFieldValue{
+		{
+			RefID: "parent",
+			Type:  sdk.WorkProjectCapabilityIssueMutationFieldsTypeWorkIssue,
+			Value: []byte(`{"ref_id":"10500","name":"ABC-12"}`),
+		},
+	}
+	mutation, err := makeCreateMutation(sdk.NewNoOpTestLogger(), authConfig{APIPath: apiPathV3}, "10000", fields)
+	assert.NoError(err)
+	assert.Equal(idValue{"10500"}, mutation.Fields["parent"])
+}
+
+func TestIsTeamManagedIssue(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/10000":
+			assert.Equal("project", r.URL.Query().Get("fields"))
+			w.Write([]byte(`{"id":"10000","fields":{"project":{"id":"1","key":"ABC"}}}`))
+		case "/rest/api/3/issue/10001":
+			w.Write([]byte(`{"id":"10001","fields":{"project":{"id":"2","key":"DEF"}}}`))
+		case "/rest/api/3/project/1":
+			w.Write([]byte(`{"id":"1","key":"ABC","simplified":true,"style":"next-gen"}`))
+		case "/rest/api/3/project/2":
+			w.Write([]byte(`{"id":"2","key":"DEF","simplified":false,"style":"classic"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	i := newMockIntegration()
+	authConfig := authConfig{APIURL: server.URL, APIPath: apiPathV3}
+	teamManaged, err := i.isTeamManagedIssue(authConfig, "10000")
+	assert.NoError(err)
+	assert.True(teamManaged)
+	teamManaged, err = i.isTeamManagedIssue(authConfig, "10001")
+	assert.NoError(err)
+	assert.False(teamManaged)
+}
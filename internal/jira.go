@@ -14,6 +14,10 @@ type JiraIntegration struct {
 	httpmanager sdk.HTTPClientManager
 	client      sdk.GraphQLClient
 	lock        sync.Mutex
+	// rateLimiters are shared by all the requests to a site, by api url
+	rateLimiters map[string]*rateLimiter
+	// apis are the api versions and deployment types we detected, by api url
+	apis map[string]apiInfo
 }
 
 var _ sdk.Integration = (*JiraIntegration)(nil)
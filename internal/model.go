@@ -47,6 +47,7 @@ type Avatars struct {
 
 type changeLogItem struct {
 	Field      string `json:"field"`
+	FieldID    string `json:"fieldId"` // this is only on cloud and not server
 	FieldType  string `json:"fieldtype"`
 	From       string `json:"from"`
 	FromString string `json:"fromString"`
@@ -54,6 +55,22 @@ type changeLogItem struct {
 	ToString   string `json:"toString"`
 }
 
+type changeLogHistory struct {
+	ID      string          `json:"id"`
+	Author  user            `json:"author"`
+	Created string          `json:"created"`
+	Items   []changeLogItem `json:"items"`
+}
+
+// changeLogQueryResult is a page of results from the issue changelog api
+type changeLogQueryResult struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	IsLast     bool               `json:"isLast"`
+	Values     []changeLogHistory `json:"values"`
+}
+
 type issueSource struct {
 	ID  string `json:"id"`
 	Key string `json:"key"`
@@ -63,12 +80,10 @@ type issueSource struct {
 	// with customfield_.
 	Fields    map[string]interface{} `json:"fields"`
 	Changelog struct {
-		Histories []struct {
-			ID      string          `json:"id"`
-			Author  user            `json:"author"`
-			Created string          `json:"created"`
-			Items   []changeLogItem `json:"items"`
-		} `json:"histories"`
+		StartAt    int                `json:"startAt"`
+		MaxResults int                `json:"maxResults"`
+		Total      int                `json:"total"`
+		Histories  []changeLogHistory `json:"histories"`
 	} `json:"changelog"`
 	Transitions []transitionSource `json:"transitions"`
 }
@@ -102,15 +117,13 @@ type issueFields struct {
 		ID  string `json:"id"`
 		Key string `json:"key"`
 	} `json:"project"`
-	Description json.RawMessage `json:"description"`
-	Comment     struct {
-		Comments []comment
-	} `json:"comment"`
-	Summary string `json:"summary"`
-	DueDate string `json:"duedate"`
-	Created string `json:"created"`
-	Updated string `json:"updated"`
-	Parent  *struct {
+	Description json.RawMessage    `json:"description"`
+	Comment     commentQueryResult `json:"comment"`
+	Summary     string             `json:"summary"`
+	DueDate     string             `json:"duedate"`
+	Created     string             `json:"created"`
+	Updated     string             `json:"updated"`
+	Parent      *struct {
 		ID  string `json:"id"`
 		Key string `json:"key"`
 	} `json:"parent,omitempty"`
@@ -119,8 +132,10 @@ type issueFields struct {
 		Name string `json:"name"`
 	} `json:"priority"`
 	IssueType struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
+		ID             string `json:"id"`
+		Name           string `json:"name"`
+		Subtask        bool   `json:"subtask"`
+		HierarchyLevel int    `json:"hierarchyLevel"`
 	} `json:"issuetype"`
 	Status struct {
 		Name string `json:"name"`
@@ -142,19 +157,22 @@ type issueFields struct {
 		OutwardIssue linkedIssue `json:"outwardIssue"`
 		InwardIssue  linkedIssue `json:"inwardIssue"`
 	} `json:"issuelinks"`
-	Attachment []struct {
-		ID       string `json:"id"`
-		Filename string `json:"filename"`
-		Author   struct {
-			Key       string `json:"key"`
-			AccountID string `json:"accountId"`
-		} `json:"author"`
-		Created   string `json:"created"`
-		Size      int    `json:"size"`
-		MimeType  string `json:"mimeType"`
-		Content   string `json:"content"`
-		Thumbnail string `json:"thumbnail"`
-	} `json:"attachment"`
+	Attachment []attachment   `json:"attachment"`
+	Security   *securityLevel `json:"security"`
+}
+
+type attachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Author   struct {
+		Key       string `json:"key"`
+		AccountID string `json:"accountId"`
+	} `json:"author"`
+	Created   string `json:"created"`
+	Size      int    `json:"size"`
+	MimeType  string `json:"mimeType"`
+	Content   string `json:"content"`
+	Thumbnail string `json:"thumbnail"`
 }
 
 type issueQueryResult struct {
@@ -217,17 +235,25 @@ type issuePriority struct {
 }
 
 type issueType struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Icon        string `json:"iconUrl"`
-	Subtask     bool   `json:"subtask"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Icon           string `json:"iconUrl"`
+	Subtask        bool   `json:"subtask"`
+	HierarchyLevel int    `json:"hierarchyLevel"`
+}
+
+type customFieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items"`
+	Custom string `json:"custom"`
 }
 
 type customFieldQueryResult struct {
-	ID   string `json:"id"`
-	Key  string `json:"key"` // this is only on cloud and not server
-	Name string `json:"name"`
+	ID     string            `json:"id"`
+	Key    string            `json:"key"` // this is only on cloud and not server
+	Name   string            `json:"name"`
+	Schema customFieldSchema `json:"schema"`
 }
 
 type issueCreateMeta struct {
@@ -265,6 +291,7 @@ type issueTypeField struct {
 type issueTypeFieldSchema struct {
 	Type   string `json:"type"`
 	System string `json:"system"`
+	Custom string `json:"custom"`
 }
 
 type allowedValueComponent struct {
@@ -26,7 +26,8 @@ func (i *JiraIntegration) IdentifyOAuth1User(identifier sdk.Identifier, url stri
 	if err != nil {
 		return nil, err
 	}
-	client := i.httpmanager.New(sdk.JoinURL(theurl, "/rest/api/3/myself"), nil)
+	// oauth1 is how server and data center authenticate, which don't have v3, and myself is the same in both versions
+	client := i.httpmanager.New(sdk.JoinURL(theurl, apiPathV2, "/myself"), nil)
 	var resp user
 	if _, err := client.Get(&resp, authConfig.Middleware...); err != nil {
 		return nil, err
@@ -12,6 +12,26 @@ import (
 	"github.com/pinpt/agent/v4/sdk"
 )
 
+const (
+	projectTypeSoftware         = "software"
+	projectTypeBusiness         = "business"
+	projectTypeServiceDesk      = "service_desk"
+	projectTypeProductDiscovery = "product_discovery"
+)
+
+// validProjectTypes are the project types which can be configured for export
+var validProjectTypes = map[string]bool{
+	projectTypeSoftware:         true,
+	projectTypeBusiness:         true,
+	projectTypeServiceDesk:      true,
+	projectTypeProductDiscovery: true,
+}
+
+// isTeamManaged returns true for team-managed projects, which jira used to call next-gen
+func (p project) isTeamManaged() bool {
+	return p.Simplified && p.Style == "next-gen"
+}
+
 func (p project) ToModel(customerID string, integrationInstanceID string, websiteURL string, issueTypes []sdk.WorkProjectIssueTypes, resolutions []sdk.WorkProjectIssueResolutions) (*sdk.WorkProject, error) {
 	project := &sdk.WorkProject{}
 	project.CustomerID = customerID
@@ -133,16 +153,17 @@ func handleBuiltinField(field issueTypeField) (sdk.WorkProjectCapabilityIssueMut
 			Values:      vals,
 		}, true, nil
 	default:
-		// try matching by name
-		switch field.Name {
-		case "Epic Link":
+		// try matching by the schema type, and then by name
+		custom := field.Schema.Custom
+		switch {
+		case custom == schemaCustomEpicLink, custom == "" && field.Name == "Epic Link":
 			return sdk.WorkProjectCapabilityIssueMutationFields{
 				Description: sdk.StringPointer("The epic this issue is part of"),
 				Name:        field.Name,
 				RefID:       field.Key,
 				Type:        sdk.WorkProjectCapabilityIssueMutationFieldsTypeEpic,
 			}, true, nil
-		case "Epic Name":
+		case custom == schemaCustomEpicName, custom == "" && field.Name == "Epic Name":
 			return sdk.WorkProjectCapabilityIssueMutationFields{
 				Description: sdk.StringPointer("The short name for this epic"),
 				Name:        field.Name,
@@ -267,7 +288,7 @@ func (i *JiraIntegration) createProjectCapability(logger sdk.Logger, state sdk.S
 	capability.KanbanBoards = true
 	capability.LinkedIssues = true
 	capability.Parents = true
-	if jiraProject.Simplified && jiraProject.Style == "next-gen" {
+	if jiraProject.isTeamManaged() {
 		capability.Priorities = false // next gen project doesn't have priorities
 	} else {
 		capability.Priorities = true
@@ -275,6 +296,7 @@ func (i *JiraIntegration) createProjectCapability(logger sdk.Logger, state sdk.S
 	capability.Resolutions = true
 	capability.Sprints = true
 	capability.StoryPoints = true
+	setProjectTypeCapabilities(&capability, jiraProject.ProjectTypeKey)
 	if createMeta != nil {
 		// NOTE: sometimes projects don't have this, need to investigate further
 		capability.IssueMutationFields, err = createMutationFields(*createMeta)
@@ -292,12 +314,37 @@ func (i *JiraIntegration) createProjectCapability(logger sdk.Logger, state sdk.S
 	return &capability, nil
 }
 
+// setProjectTypeCapabilities turns off the features that a project type doesn't have, all of them are on for software projects
+func setProjectTypeCapabilities(capability *sdk.WorkProjectCapability, projectTypeKey string) {
+	switch projectTypeKey {
+	case projectTypeBusiness:
+		// work management projects have no agile boards, so no sprints either
+		capability.KanbanBoards = false
+		capability.Sprints = false
+		capability.StoryPoints = false
+	case projectTypeServiceDesk:
+		// service management projects have queues instead of boards and requests aren't planned with epics
+		capability.KanbanBoards = false
+		capability.Sprints = false
+		capability.StoryPoints = false
+		capability.Epics = false
+	case projectTypeProductDiscovery:
+		// product discovery ideas are only delivered through the software projects they're linked to
+		capability.KanbanBoards = false
+		capability.Sprints = false
+		capability.StoryPoints = false
+		capability.Epics = false
+		capability.DueDates = false
+		capability.Resolutions = false
+	}
+}
+
 func setProjectExpand(qs url.Values) {
 	qs.Set("expand", "description,url,issueTypes,projectKeys,insight")
 }
 
 func (i *JiraIntegration) fetchProject(state *state, customerID, refID string) (*sdk.WorkProject, error) {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/project/", refID)
+	theurl := state.authConfig.restURL("/project/", refID)
 	client := i.httpmanager.New(theurl, nil)
 	qs := url.Values{}
 	setProjectExpand(qs)
@@ -309,6 +356,10 @@ func (i *JiraIntegration) fetchProject(state *state, customerID, refID string) (
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, nil
 	}
+	if !sliceContains(projectTypes(state.config), p.ProjectTypeKey) {
+		sdk.LogDebug(state.logger, "ignoring project which isn't one of the configured types", "project", refID, "type", p.ProjectTypeKey)
+		return nil, nil
+	}
 	resolutions, err := i.fetchIssueResolutions(state)
 	if err != nil {
 		return nil, err
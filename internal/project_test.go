@@ -166,3 +166,73 @@ func TestCantMakeMutationFields(t *testing.T) {
 	assert.True(errors.Is(err, errUnsupportedField))
 	assert.Empty(fields)
 }
+
+func TestProjectTypes(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"software"}, projectTypes(sdk.NewConfig(nil)))
+	assert.Equal([]string{"software"}, projectTypes(sdk.NewConfig(map[string]interface{}{configKeyProjectTypes: "nope"})))
+	assert.Equal([]string{"business", "service_desk"}, projectTypes(sdk.NewConfig(map[string]interface{}{configKeyProjectTypes: " Business,service_desk,business"})))
+}
+
+func TestSetProjectTypeCapabilities(t *testing.T) {
+	assert := assert.New(t)
+	var capability sdk.WorkProjectCapability
+	capability.KanbanBoards = true
+	capability.Sprints = true
+	capability.Epics = true
+	setProjectTypeCapabilities(&capability, projectTypeSoftware)
+	assert.True(capability.KanbanBoards)
+	assert.True(capability.Sprints)
+	setProjectTypeCapabilities(&capability, projectTypeServiceDesk)
+	assert.False(capability.KanbanBoards)
+	assert.False(capability.Sprints)
+	assert.False(capability.Epics)
+}
+
+func TestExportProjectTypes(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	jira.addProject("10001", "HR", 1)
+	jira.setProjectType("10001", projectTypeBusiness)
+	jira.addProject("10002", "HELP", 1)
+	jira.setProjectType("10002", projectTypeServiceDesk)
+	jira.projects[2].ProjectCategory.Name = "Support"
+
+	exported := func(export *mockExport) (map[string]*sdk.WorkProject, map[string]*sdk.WorkProjectCapability) {
+		projects := make(map[string]*sdk.WorkProject)
+		capabilities := make(map[string]*sdk.WorkProjectCapability)
+		for _, object := range export.pipe.written {
+			switch o := object.(type) {
+			case *sdk.WorkProject:
+				projects[o.Identifier] = o
+			case *sdk.WorkProjectCapability:
+				capabilities[o.RefID] = o
+			}
+		}
+		return projects, capabilities
+	}
+
+	// only software projects by default
+	export := newMockExport(jira.URL(), newMockState(), true)
+	assert.NoError(newMockIntegration().Export(export))
+	projects, _ := exported(export)
+	assert.Len(projects, 1)
+	assert.NotNil(projects["ABC"])
+
+	export = newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyProjectTypes: "software,business,service_desk"})
+	assert.NoError(newMockIntegration().Export(export))
+	projects, capabilities := exported(export)
+	assert.Len(projects, 3)
+	// the category is only ever jira's, the type shows in the capabilities
+	assert.Nil(projects["HR"].Category)
+	assert.Equal("Support", *projects["HELP"].Category)
+	assert.True(capabilities["10000"].Sprints)
+	assert.False(capabilities["10001"].Sprints)
+	assert.False(capabilities["10001"].KanbanBoards)
+	assert.True(capabilities["10001"].Epics)
+	assert.False(capabilities["10002"].Sprints)
+	assert.False(capabilities["10002"].Epics)
+}
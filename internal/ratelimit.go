@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const (
+	// defaultRateLimitWait is how long we wait when jira rate limits us without telling us for how long
+	defaultRateLimitWait = 30 * time.Second
+	// maxRateLimitWait is the longest we'll keep retrying a request while jira is rate limiting us
+	maxRateLimitWait = 30 * time.Minute
+	// pauseThreshold is the shortest wait that we tell the agent about with a pause
+	pauseThreshold = 5 * time.Second
+)
+
+// rateLimiter paces the requests to a jira site using the token bucket it describes in the rate limit headers, and
+// blocks all the requests to it when one of them is rate limited. it's shared by every client for the same site
+// easyjson:skip
+type rateLimiter struct {
+	mu           sync.Mutex
+	now          func() time.Time
+	sleep        func(time.Duration)
+	capacity     float64
+	tokens       float64
+	refillRate   float64 // tokens per second, zero until jira tells us
+	refilledAt   time.Time
+	blockedUntil time.Time
+	limitedSince time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now, sleep: time.Sleep}
+}
+
+// rateLimiter returns the limiter for the site at apiURL
+func (i *JiraIntegration) rateLimiter(apiURL string) *rateLimiter {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.rateLimiters == nil {
+		i.rateLimiters = make(map[string]*rateLimiter)
+	}
+	limiter := i.rateLimiters[apiURL]
+	if limiter == nil {
+		limiter = newRateLimiter()
+		i.rateLimiters[apiURL] = limiter
+	}
+	return limiter
+}
+
+// refill must be called with the lock held
+func (l *rateLimiter) refill(now time.Time) {
+	if l.refillRate > 0 && !l.refilledAt.IsZero() {
+		l.tokens += now.Sub(l.refilledAt).Seconds() * l.refillRate
+		if l.capacity > 0 && l.tokens > l.capacity {
+			l.tokens = l.capacity
+		}
+	}
+	l.refilledAt = now
+}
+
+// reserve takes a token for a request and returns how long to wait before making it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	var wait time.Duration
+	if now.Before(l.blockedUntil) {
+		wait = l.blockedUntil.Sub(now)
+	}
+	if l.refillRate > 0 {
+		l.refill(now)
+		l.tokens--
+		if l.tokens < 0 {
+			// we've used up the bucket so go at the rate it refills
+			if paced := time.Duration(-l.tokens / l.refillRate * float64(time.Second)); paced > wait {
+				wait = paced
+			}
+		}
+	}
+	return wait
+}
+
+// parseRateLimitReset supports both the timestamp jira cloud sends and seconds since the epoch
+func parseRateLimitReset(val string) (time.Time, bool) {
+	if val == "" {
+		return time.Time{}, false
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, val); err == nil {
+		return ts, true
+	}
+	if epoch, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(epoch, 0), true
+	}
+	return time.Time{}, false
+}
+
+// retryAfter returns when we can retry a rate limited response
+func retryAfter(now time.Time, header http.Header) time.Time {
+	until := now.Add(defaultRateLimitWait)
+	var found bool
+	if secs, err := strconv.ParseInt(header.Get("Retry-After"), 10, 64); err == nil && secs >= 0 {
+		until = now.Add(time.Duration(secs) * time.Second)
+		found = true
+	}
+	// use the reset if it's later, or if it's all we have
+	if reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset")); ok && (!found || reset.After(until)) {
+		until = reset
+	}
+	return until
+}
+
+// observe updates the limiter with a response and returns when the request can be retried if it was rate limited
+func (l *rateLimiter) observe(statusCode int, header http.Header) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	limit, lerr := strconv.ParseFloat(header.Get("X-RateLimit-Limit"), 64)
+	remaining, rerr := strconv.ParseFloat(header.Get("X-RateLimit-Remaining"), 64)
+	fillRate, ferr := strconv.ParseFloat(header.Get("X-RateLimit-FillRate"), 64)
+	interval, ierr := strconv.ParseFloat(header.Get("X-RateLimit-Interval-Seconds"), 64)
+	if ferr == nil && ierr == nil && fillRate > 0 && interval > 0 {
+		l.refill(now)
+		l.refillRate = fillRate / interval
+		if lerr == nil {
+			l.capacity = limit
+		}
+		if rerr == nil {
+			// trust what jira says is left over our own count, since other clients may share the limit
+			l.tokens = remaining
+		}
+	}
+	limited := statusCode == http.StatusTooManyRequests || (statusCode == http.StatusServiceUnavailable && header.Get("Retry-After") != "")
+	if !limited {
+		l.limitedSince = time.Time{}
+		return time.Time{}, false
+	}
+	until := retryAfter(now, header)
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+	if l.limitedSince.IsZero() {
+		l.limitedSince = now
+	}
+	return l.blockedUntil, true
+}
+
+// givingUp returns true if we've been rate limited for so long that we should stop retrying
+func (l *rateLimiter) givingUp() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.limitedSince.IsZero() && l.now().Sub(l.limitedSince) >= maxRateLimitWait
+}
+
+// rateLimitControl makes sure the pauses and resumes for a control are sent in pairs, even with concurrent requests
+// easyjson:skip
+type rateLimitControl struct {
+	mu      sync.Mutex
+	logger  sdk.Logger
+	control sdk.Control
+	paused  bool
+}
+
+func (c *rateLimitControl) pause(until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sdk.LogInfo(c.logger, "rate limited", "until", until)
+	if c.paused || c.control == nil {
+		return
+	}
+	if err := c.control.Paused(until); err != nil {
+		sdk.LogError(c.logger, "error sending paused", "err", err)
+		return
+	}
+	c.paused = true
+}
+
+func (c *rateLimitControl) resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.paused {
+		return
+	}
+	sdk.LogInfo(c.logger, "rate limit wake up")
+	if err := c.control.Resumed(); err != nil {
+		sdk.LogError(c.logger, "error sending resumed", "err", err)
+	}
+	c.paused = false
+}
+
+// withRateLimit returns the middleware which paces requests before they are sent and has the http client retry the
+// ones that are rate limited, for as long as maxRateLimitWait. control is told when we're waiting and may be nil
+func withRateLimit(logger sdk.Logger, limiter *rateLimiter, control sdk.Control) sdk.WithHTTPOption {
+	rc := &rateLimitControl{logger: logger, control: control}
+	return func(opt *sdk.HTTPOptions) error {
+		if opt.Response == nil {
+			if wait := limiter.reserve(); wait > 0 {
+				if wait >= pauseThreshold {
+					rc.pause(limiter.now().Add(wait))
+				}
+				limiter.sleep(wait)
+			}
+			rc.resume()
+			return nil
+		}
+		until, limited := limiter.observe(opt.Response.StatusCode, opt.Response.Headers)
+		if !limited {
+			return nil
+		}
+		rc.pause(until)
+		if limiter.givingUp() {
+			sdk.LogWarn(logger, "giving up after being rate limited for too long", "wait", maxRateLimitWait)
+			return nil
+		}
+		// have the client retry once we've waited, which happens before the request is sent again
+		opt.ShouldRetry = true
+		opt.RetryAfter = time.Millisecond
+		opt.Deadline = until.Add(time.Minute)
+		return nil
+	}
+}
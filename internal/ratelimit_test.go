@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRateLimiter(now *time.Time) *rateLimiter {
+	limiter := newRateLimiter()
+	limiter.now = func() time.Time { return *now }
+	limiter.sleep = func(d time.Duration) { *now = now.Add(d) }
+	return limiter
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	assert.Equal(now.Add(defaultRateLimitWait), retryAfter(now, header))
+	header.Set("Retry-After", "10")
+	assert.Equal(now.Add(10*time.Second), retryAfter(now, header))
+	header.Set("X-RateLimit-Reset", now.Add(time.Minute).Format(time.RFC3339))
+	assert.Equal(now.Add(time.Minute), retryAfter(now, header))
+	header.Del("Retry-After")
+	header.Set("X-RateLimit-Reset", now.Add(5*time.Second).Format(time.RFC3339))
+	assert.Equal(now.Add(5*time.Second), retryAfter(now, header))
+}
+
+func TestRateLimiterPacesRequests(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+	// nothing to go on until jira tells us about the limit
+	assert.Equal(time.Duration(0), limiter.reserve())
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "10")
+	header.Set("X-RateLimit-Remaining", "2")
+	header.Set("X-RateLimit-FillRate", "5")
+	header.Set("X-RateLimit-Interval-Seconds", "1")
+	_, limited := limiter.observe(http.StatusOK, header)
+	assert.False(limited)
+	assert.Equal(time.Duration(0), limiter.reserve())
+	assert.Equal(time.Duration(0), limiter.reserve())
+	assert.Equal(200*time.Millisecond, limiter.reserve())
+	assert.Equal(400*time.Millisecond, limiter.reserve())
+	// the bucket refills while we wait
+	now = now.Add(time.Second)
+	assert.Equal(time.Duration(0), limiter.reserve())
+}
+
+func TestRateLimiterBlocksWhenRateLimited(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+	header := http.Header{}
+	header.Set("Retry-After", "20")
+	until, limited := limiter.observe(http.StatusTooManyRequests, header)
+	assert.True(limited)
+	assert.Equal(now.Add(20*time.Second), until)
+	// all requests to the site wait, not just the one that was rate limited
+	assert.Equal(20*time.Second, limiter.reserve())
+	now = now.Add(maxRateLimitWait)
+	limiter.observe(http.StatusTooManyRequests, header)
+	assert.True(limiter.givingUp())
+	limiter.observe(http.StatusOK, http.Header{})
+	assert.False(limiter.givingUp())
+}
+
+func TestWithRateLimitPausesAndResumes(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestRateLimiter(&now)
+	export := newMockExport("", newMockState(), false)
+	option := withRateLimit(sdk.NewNoOpTestLogger(), limiter, export)
+	header := http.Header{}
+	header.Set("Retry-After", "60")
+	opt := &sdk.HTTPOptions{Response: &sdk.HTTPResponse{StatusCode: http.StatusTooManyRequests, Headers: header}}
+	assert.NoError(option(opt))
+	assert.True(opt.ShouldRetry)
+	assert.True(opt.Deadline.After(now.Add(time.Minute)))
+	assert.Equal(1, export.paused)
+	assert.Equal(0, export.resumed)
+	// the retry waits until we're no longer rate limited
+	assert.NoError(option(&sdk.HTTPOptions{}))
+	assert.Equal(time.Date(2020, 10, 1, 0, 1, 0, 0, time.UTC), now)
+	assert.Equal(1, export.paused)
+	assert.Equal(1, export.resumed)
+}
+
+func TestExportRetriesRateLimitedRequests(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.throttled = 1
+
+	export := newMockExport(jira.URL(), newMockState(), true)
+	assert.NoError(newMockIntegration().Export(export))
+	var issues int
+	for _, object := range export.pipe.written {
+		if _, ok := object.(*sdk.WorkIssue); ok {
+			issues++
+		}
+	}
+	assert.Equal(5, issues)
+	assert.Equal(1, export.paused)
+	assert.Equal(1, export.resumed)
+}
+
+// timedOutHTTPManager times out requests to urls containing path, like the agent's client does when it gives up retrying
+type timedOutHTTPManager struct {
+	mockHTTPManager
+	path string
+}
+
+func (m *timedOutHTTPManager) New(url string, headers map[string]string) sdk.HTTPClient {
+	if strings.Contains(url, m.path) {
+		return &timedOutHTTPClient{}
+	}
+	return m.mockHTTPManager.New(url, headers)
+}
+
+type timedOutHTTPClient struct {
+	sdk.HTTPClient
+}
+
+func (c *timedOutHTTPClient) Get(out interface{}, options ...sdk.WithHTTPOption) (*sdk.HTTPResponse, error) {
+	return nil, sdk.ErrTimedOut
+}
+
+func TestExportReturnsErrorWhenRequestTimesOut(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	httpmanager := &timedOutHTTPManager{path: "/rest/api/3/search"}
+	integration := &JiraIntegration{
+		manager:     &mockManager{httpmanager: httpmanager},
+		httpmanager: httpmanager,
+	}
+	err := integration.Export(newMockExport(jira.URL(), newMockState(), false))
+	assert.True(errors.Is(err, sdk.ErrTimedOut))
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const (
+	lastIssueReconcileStateKey    = "last_issue_reconcile_ts"
+	projectIssueIDsStateKeyPrefix = "project_issue_ids_"
+)
+
+// reconcileInterval is how often we check for deleted issues we missed the webhook for
+const reconcileInterval = 24 * time.Hour
+
+const reconcilePageSize = 1000 // jira will return less than this if it wants to
+
+// encodeIssueIDs returns the ids sorted as base 36 deltas, which is a lot smaller than a list of ids
+func encodeIssueIDs(ids []int64) string {
+	sorted := make([]int64, len(ids))
+	copy(sorted, ids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var sb strings.Builder
+	var last int64
+	for n, id := range sorted {
+		if n > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatInt(id-last, 36))
+		last = id
+	}
+	return sb.String()
+}
+
+func decodeIssueIDs(val string) ([]int64, error) {
+	ids := make([]int64, 0)
+	if val == "" {
+		return ids, nil
+	}
+	var last int64
+	for _, delta := range strings.Split(val, ",") {
+		d, err := strconv.ParseInt(delta, 36, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding issue ids: %w", err)
+		}
+		last += d
+		ids = append(ids, last)
+	}
+	return ids, nil
+}
+
+// fetchProjectIssueIDs returns the ids of all the issues in the project, paging by key so that issues
+// being deleted while we page won't cause any to be skipped
+func (i *JiraIntegration) fetchProjectIssueIDs(state *state, projectKey string) ([]int64, error) {
+	theurl := state.authConfig.restURL("/search")
+	client := i.httpmanager.New(theurl, nil)
+	queryParams := make(url.Values)
+	queryParams.Set("fields", "id")
+	queryParams.Set("maxResults", strconv.Itoa(reconcilePageSize))
+	ids := make([]int64, 0)
+	var afterKey string
+	for {
+		queryParams.Set("jql", issueSearchResumableJQL([]string{projectKey}, afterKey, state.issueFilter))
+		var resp issueQueryResult
+		if _, err := client.Get(&resp, append(state.authConfig.Middleware, sdk.WithGetQueryParameters(queryParams))...); err != nil {
+			return nil, err
+		}
+		for _, issue := range resp.Issues {
+			id, err := strconv.ParseInt(issue.ID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing issue id %s: %w", issue.ID, err)
+			}
+			ids = append(ids, id)
+		}
+		if len(resp.Issues) == 0 || len(resp.Issues) >= resp.Total {
+			break
+		}
+		afterKey = resp.Issues[len(resp.Issues)-1].Key
+	}
+	return ids, nil
+}
+
+// reconcileDeletedIssues will compare the issues in each project with the ones we found the last time and deactivate any
+// which no longer exist, in case we didn't get (or can't get) the webhook for the delete. unless force is true, this is only
+// done once every reconcileInterval
+func (i *JiraIntegration) reconcileDeletedIssues(state *state, projectKeys []string, force bool) error {
+	exportState := state.export.State()
+	if !force {
+		var lastStr string
+		if _, err := exportState.Get(lastIssueReconcileStateKey, &lastStr); err != nil {
+			return fmt.Errorf("error getting last issue reconcile time from state: %w", err)
+		}
+		if last, _ := time.Parse(time.RFC3339Nano, lastStr); time.Since(last) < reconcileInterval {
+			sdk.LogDebug(state.logger, "skipping deleted issue reconcile", "last", lastStr)
+			return nil
+		}
+	}
+	started := time.Now()
+	previous := make(map[int64]bool)
+	current := make(map[int64]bool)
+	currentByProject := make(map[string][]int64)
+	for _, projectKey := range projectKeys {
+		ids, err := i.fetchProjectIssueIDs(state, projectKey)
+		if err != nil {
+			// don't treat the issues as deleted if we can't list them
+			sdk.LogWarn(state.logger, "error fetching issue ids for project, skipping reconcile for it", "project", projectKey, "err", err)
+			continue
+		}
+		var val string
+		if _, err := exportState.Get(projectIssueIDsStateKeyPrefix+projectKey, &val); err != nil {
+			return fmt.Errorf("error getting project issue ids from state: %w", err)
+		}
+		previousIDs, err := decodeIssueIDs(val)
+		if err != nil {
+			return err
+		}
+		for _, id := range previousIDs {
+			previous[id] = true
+		}
+		for _, id := range ids {
+			current[id] = true
+		}
+		currentByProject[projectKey] = ids
+	}
+	// compare across all the projects since an issue moved to another project isn't deleted
+	var deleted int
+	for id := range previous {
+		if current[id] {
+			continue
+		}
+		refID := strconv.FormatInt(id, 10)
+		sdk.LogDebug(state.logger, "deactivating issue which no longer exists", "issue", refID)
+		if err := state.pipe.Write(sdk.NewWorkIssueDeactivate(state.export.CustomerID(), state.integrationInstanceID, refID, refType)); err != nil {
+			return err
+		}
+		if err := state.attachments.removeIssue(refID); err != nil {
+			sdk.LogWarn(state.logger, "error deleting the mirrored attachments of a deleted issue", "issue", refID, "err", err)
+		}
+		deleted++
+	}
+	for projectKey, ids := range currentByProject {
+		if err := exportState.Set(projectIssueIDsStateKeyPrefix+projectKey, encodeIssueIDs(ids)); err != nil {
+			return fmt.Errorf("error saving project issue ids to state: %w", err)
+		}
+		if err := state.comments.prune(projectKey, ids); err != nil {
+			return err
+		}
+	}
+	if err := state.comments.save(); err != nil {
+		return err
+	}
+	if err := exportState.Set(lastIssueReconcileStateKey, started.Format(time.RFC3339Nano)); err != nil {
+		return fmt.Errorf("error saving last issue reconcile time to state: %w", err)
+	}
+	sdk.LogInfo(state.logger, "deleted issue reconcile completed", "duration", time.Since(started), "issues", len(current), "deleted", deleted)
+	return nil
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/integration-sdk/agent"
+	"github.com/pinpt/integration-sdk/work"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeIssueIDs(t *testing.T) {
+	assert := assert.New(t)
+	ids := []int64{10234, 10001, 10002, 99999, 10000}
+	val := encodeIssueIDs(ids)
+	assert.Equal("7ps,1,1,6g,1x9h", val)
+	decoded, err := decodeIssueIDs(val)
+	assert.NoError(err)
+	assert.Equal([]int64{10000, 10001, 10002, 10234, 99999}, decoded)
+	decoded, err = decodeIssueIDs("")
+	assert.NoError(err)
+	assert.Empty(decoded)
+}
+
+func deactivatedIssues(pipe *mockPipe) []string {
+	refIDs := make([]string, 0)
+	for _, object := range pipe.written {
+		if update, ok := object.(*agent.UpdateData); ok && update.Model == work.IssueModelName.String() && update.Set["active"] == "false" {
+			refIDs = append(refIDs, update.RefID)
+		}
+	}
+	return refIDs
+}
+
+func TestExportReconcilesDeletedIssues(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 30)
+	jira.addProject("10001", "DEF", 5)
+
+	integration := newMockIntegration()
+	state := newMockState()
+	assert.NoError(integration.Export(newMockExport(jira.URL(), state, true)))
+	assert.True(state.Exists(projectIssueIDsStateKeyPrefix + "10000"))
+
+	// delete a couple and move one to the other project
+	issues := jira.issues["10000"]
+	deleted := []string{issues[3].ID, issues[20].ID}
+	moved := issues[25]
+	jira.issues["10000"] = append(append(append([]issueSource{}, issues[:3]...), issues[4:20]...), issues[21:25]...)
+	jira.issues["10000"] = append(jira.issues["10000"], issues[26:]...)
+	moved.Key = "DEF-6"
+	jira.issues["10001"] = append(jira.issues["10001"], moved)
+
+	// we already reconciled recently so it shouldn't happen yet
+	export := newMockExport(jira.URL(), state, false)
+	assert.NoError(integration.Export(export))
+	assert.Empty(deactivatedIssues(export.pipe))
+
+	assert.NoError(state.Set(lastIssueReconcileStateKey, time.Now().Add(-reconcileInterval).Format(time.RFC3339Nano)))
+	export = newMockExport(jira.URL(), state, false)
+	assert.NoError(integration.Export(export))
+	assert.ElementsMatch(deleted, deactivatedIssues(export.pipe))
+
+	var active int
+	for _, object := range export.pipe.written {
+		if _, ok := object.(*sdk.WorkIssue); ok {
+			active++
+		}
+	}
+	assert.Equal(33, active)
+}
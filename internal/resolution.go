@@ -7,7 +7,7 @@ import (
 )
 
 func (i *JiraIntegration) fetchIssueResolutions(state *state) ([]sdk.WorkProjectIssueResolutions, error) {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/resolution")
+	theurl := state.authConfig.restURL("/resolution")
 	client := i.httpmanager.New(theurl, nil)
 	var resp []struct {
 		ID   string `json:"id"`
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+// restrictionPolicy is what we do with issues under a security level and comments which only a role or group can see
+type restrictionPolicy string
+
+const (
+	// restrictionPolicyExport exports them like everything else, with the security level of an issue in its tags
+	restrictionPolicyExport restrictionPolicy = "export"
+	// restrictionPolicyRedact exports them without the description, comment body or mirrored attachment content
+	restrictionPolicyRedact restrictionPolicy = "redact"
+	// restrictionPolicySkip doesn't export them, and deactivates them if they were exported before
+	restrictionPolicySkip restrictionPolicy = "skip"
+)
+
+// commentVisibility is the role or group which a comment is restricted to
+type commentVisibility struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// securityLevel is the issue security level which limits who can see an issue and its comments
+type securityLevel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// securityLevelTag is the tag which records the security level of an issue, since the datamodel has no field for it
+func securityLevelTag(level *securityLevel) string {
+	return "Security Level:" + level.Name
+}
+
+// redacted returns true if the issue has a security level which the restriction policy redacts
+func (i issueSource) redacted(restriction restrictionPolicy) bool {
+	return restriction == restrictionPolicyRedact && i.Fields["security"] != nil
+}
+
+// fetchIssueSecurityLevel returns the security level of an issue, or nil if everyone who can see the project can see it
+func (i *JiraIntegration) fetchIssueSecurityLevel(authConfig authConfig, issueRefID string) (*securityLevel, error) {
+	theurl := authConfig.restURL("/issue/", issueRefID)
+	client := i.httpmanager.New(theurl, nil)
+	qs := url.Values{}
+	qs.Set("fields", "security")
+	var resp struct {
+		Fields struct {
+			Security *securityLevel `json:"security"`
+		} `json:"fields"`
+	}
+	if _, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
+		return nil, fmt.Errorf("error fetching security level of issue %s: %w", issueRefID, err)
+	}
+	return resp.Fields.Security, nil
+}
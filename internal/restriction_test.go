@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/pinpt/agent/v4/sdk/sdktest"
+	"github.com/pinpt/integration-sdk/agent"
+	"github.com/pinpt/integration-sdk/work"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestrictedContent(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(restrictionPolicyExport, restrictedContent(sdk.NewConfig(nil)))
+	assert.Equal(restrictionPolicyRedact, restrictedContent(sdk.NewConfig(map[string]interface{}{configKeyRestrictedContent: " Redact "})))
+	assert.NoError(validateRestrictedContent(restrictionPolicySkip))
+	assert.EqualError(validateRestrictedContent(restrictedContent(sdk.NewConfig(map[string]interface{}{configKeyRestrictedContent: "hide"}))), "unknown restricted_content policy: hide")
+}
+
+func newRestrictedIssueSource() issueSource {
+	return issueSource{
+		ID:  "10000",
+		Key: "ABC-1",
+		Fields: map[string]interface{}{
+			"project":     map[string]interface{}{"id": "1", "key": "ABC"},
+			"labels":      []interface{}{"bug"},
+			"description": "the password is hunter2",
+			"security":    map[string]interface{}{"id": "10100", "name": "Internal"},
+			"comment": map[string]interface{}{
+				"total": 1,
+				"comments": []interface{}{
+					map[string]interface{}{"id": "1", "body": "me too", "created": "2020-10-01T10:00:00.000+0000", "updated": "2020-10-01T10:00:00.000+0000"},
+				},
+			},
+		},
+	}
+}
+
+func TestIssueToModelSecurityLevel(t *testing.T) {
+	assert := assert.New(t)
+	source := newRestrictedIssueSource()
+	sm := newSprintManager("1234", nil, &stats{}, "1", true)
+
+	issue, comments, err := source.ToModel("1234", "1", nil, sm, &mockUserManager{}, nil, customFieldIDs{}, "https://example.atlassian.net", restrictionPolicyExport, false)
+	assert.NoError(err)
+	assert.Equal([]string{"bug", "Security Level:Internal"}, issue.Tags)
+	assert.Contains(issue.Description, "hunter2")
+	assert.Len(comments, 1)
+	assert.Contains(comments[0].Body, "me too")
+
+	issue, comments, err = source.ToModel("1234", "1", nil, sm, &mockUserManager{}, nil, customFieldIDs{}, "https://example.atlassian.net", restrictionPolicyRedact, false)
+	assert.NoError(err)
+	assert.Equal([]string{"bug"}, issue.Tags)
+	assert.Empty(issue.Description)
+	// the security level of the issue covers its comments too
+	assert.Len(comments, 1)
+	assert.Empty(comments[0].Body)
+
+	issue, comments, err = source.ToModel("1234", "1", nil, sm, &mockUserManager{}, nil, customFieldIDs{}, "https://example.atlassian.net", restrictionPolicySkip, false)
+	assert.NoError(err)
+	assert.Nil(issue)
+	assert.Nil(comments)
+}
+
+func TestCommentToModelVisibility(t *testing.T) {
+	assert := assert.New(t)
+	c := comment{
+		ID:         "1",
+		Body:       json.RawMessage(`"for admins only"`),
+		Created:    "2020-10-01T10:00:00.000+0000",
+		Updated:    "2020-10-01T10:00:00.000+0000",
+		Visibility: &commentVisibility{Type: "role", Value: "Administrators"},
+	}
+	model, err := c.ToModel("1234", "1", "https://example.atlassian.net", &mockUserManager{}, "p", "i", "ABC-1", restrictionPolicyExport, nil)
+	assert.NoError(err)
+	assert.Contains(model.Body, "for admins only")
+	model, err = c.ToModel("1234", "1", "https://example.atlassian.net", &mockUserManager{}, "p", "i", "ABC-1", restrictionPolicyRedact, nil)
+	assert.NoError(err)
+	assert.Equal("1", model.RefID)
+	assert.Empty(model.Body)
+	model, err = c.ToModel("1234", "1", "https://example.atlassian.net", &mockUserManager{}, "p", "i", "ABC-1", restrictionPolicySkip, nil)
+	assert.NoError(err)
+	assert.Nil(model)
+	// comments everyone can see are exported as is
+	c.Visibility = nil
+	model, err = c.ToModel("1234", "1", "https://example.atlassian.net", &mockUserManager{}, "p", "i", "ABC-1", restrictionPolicySkip, nil)
+	assert.NoError(err)
+	assert.Contains(model.Body, "for admins only")
+}
+
+func TestExportSkipsRestrictedContent(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 2)
+	secured := &jira.issues["10000"][0]
+	jira.setComments(secured, []comment{{ID: "1", Body: jira.content("secret"), Created: "2020-10-01T10:00:00.000+0000", Updated: "2020-10-01T10:00:00.000+0000"}}, 1)
+	jira.setComments(&jira.issues["10000"][1], []comment{
+		{ID: "2", Body: jira.content("public"), Created: "2020-10-01T10:00:00.000+0000", Updated: "2020-10-01T10:00:00.000+0000"},
+		{ID: "3", Body: jira.content("private"), Created: "2020-10-01T10:00:00.000+0000", Updated: "2020-10-01T10:00:00.000+0000", Visibility: &commentVisibility{Type: "group", Value: "staff"}},
+	}, 2)
+
+	// export everything first, then secure the issue
+	integration := newMockIntegration()
+	state := newMockState()
+	export := newMockExport(jira.URL(), state, true)
+	export.config.Merge(map[string]interface{}{configKeyRestrictedContent: "skip"})
+	assert.NoError(integration.Export(export))
+	secured.Fields["security"] = map[string]interface{}{"id": "10100", "name": "Internal"}
+	export = newMockExport(jira.URL(), state, true)
+	export.config.Merge(map[string]interface{}{configKeyRestrictedContent: "skip"})
+	assert.NoError(integration.Export(export))
+
+	issues := make([]string, 0)
+	comments := make([]string, 0)
+	deactivated := make(map[string][]string)
+	for _, object := range export.pipe.written {
+		switch v := object.(type) {
+		case *sdk.WorkIssue:
+			issues = append(issues, v.RefID)
+		case *sdk.WorkIssueComment:
+			comments = append(comments, v.RefID)
+		case *agent.UpdateData:
+			if v.Set["active"] == "false" {
+				deactivated[v.Model] = append(deactivated[v.Model], v.RefID)
+			}
+		}
+	}
+	assert.Equal([]string{jira.issues["10000"][1].ID}, issues)
+	assert.Equal([]string{"2"}, comments)
+	assert.Equal([]string{secured.ID}, deactivated[work.IssueModelName.String()])
+	assert.Equal([]string{"1"}, deactivated[work.IssueCommentModelName.String()])
+
+	export = newMockExport(jira.URL(), newMockState(), true)
+	export.config.Merge(map[string]interface{}{configKeyRestrictedContent: "hide"})
+	assert.EqualError(integration.Export(export), "unknown restricted_content policy: hide")
+}
+
+// withSecurityLevel adds a security level to the issue of a webhook
+func withSecurityLevel(raw []byte) []byte {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		panic(err)
+	}
+	payload["issue"].(map[string]interface{})["fields"].(map[string]interface{})["security"] = map[string]interface{}{"id": "10100", "name": "Internal"}
+	buf, _ := json.Marshal(payload)
+	return buf
+}
+
+func TestWebhookJiraIssueUpdatedSecurityLevel(t *testing.T) {
+	assert := assert.New(t)
+	i := JiraIntegration{}
+	logger := sdk.NewNoOpTestLogger()
+
+	webhook := newMockWebHook("testdata/jira:issue_updated.tags.json")
+	webhook.raw = withSecurityLevel(webhook.raw)
+	assert.NoError(i.webhookUpdateIssue(logger, webhook))
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.EqualValues(`["signal","Security Level:Internal"]`, update.Set["tags"])
+
+	webhook = newMockWebHook("testdata/jira:issue_updated.description.json")
+	webhook.raw = withSecurityLevel(webhook.raw)
+	webhook.config.Merge(map[string]interface{}{configKeyRestrictedContent: "redact"})
+	assert.NoError(i.webhookUpdateIssue(logger, webhook))
+	update = webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.NotContains(update.Set, "description")
+
+	webhook = newMockWebHook("testdata/jira:issue_updated.description.json")
+	webhook.raw = withSecurityLevel(webhook.raw)
+	webhook.config.Merge(map[string]interface{}{configKeyRestrictedContent: "skip"})
+	assert.NoError(i.webhookUpdateIssue(logger, webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	update = webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.EqualValues("false", update.Set["active"])
+}
+
+func TestExportAttachmentsOfRedactedIssue(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	secured := &jira.issues["10000"][0]
+	jira.addAttachment(secured, "1", "image/png", []byte("png"))
+
+	// mirror it first, then secure the issue
+	integration, manager := newMockAttachmentIntegration()
+	state := newMockState()
+	export := newMockExport(jira.URL(), state, true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyRestrictedContent: "redact"})
+	assert.NoError(integration.Export(export))
+	assert.Len(manager.stored, 1)
+	secured.Fields["security"] = map[string]interface{}{"id": "10100", "name": "Internal"}
+	export = newMockExport(jira.URL(), state, true)
+	export.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyRestrictedContent: "redact"})
+	assert.NoError(integration.Export(export))
+	attachments := exportedAttachments(export.pipe)
+	assert.Len(attachments, 1)
+	assert.Empty(attachments["1"].AttachmentID)
+	assert.Empty(manager.stored)
+	assert.False(state.Exists(issueAttachmentsStateKeyPrefix + secured.ID))
+	assert.Equal([]string{"1"}, jira.downloads)
+}
+
+func TestWebhookAttachmentOfRestrictedIssue(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 1)
+	secured := &jira.issues["10000"][0]
+	secured.Fields["security"] = map[string]interface{}{"id": "10100", "name": "Internal"}
+	jira.attachments["10001"] = []byte("%PDF")
+
+	integration, manager := newMockAttachmentIntegration()
+	newWebhook := func(event string, policy string) *mockWebHook {
+		webhook := &mockWebHook{
+			raw:   []byte(fmt.Sprintf(`{"webhookEvent":%q,"attachment":{"id":"10001","issueId":%q,"filename":"spec.pdf","created":"2020-10-01T10:00:00.000+0000","size":4,"mimeType":"application/pdf"}}`, event, secured.ID)),
+			data:  make(map[string]interface{}),
+			pipe:  &sdktest.MockPipe{},
+			state: newMockState(),
+		}
+		assert.NoError(webhook.config.Parse(makeMockAuth(jira.URL())))
+		webhook.config.Merge(map[string]interface{}{configKeyMirrorAttachments: true, configKeyRestrictedContent: policy})
+		return webhook
+	}
+
+	// redacted issues get the attachment without its content
+	webhook := newWebhook("attachment_created", "redact")
+	assert.NoError(integration.WebHook(webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	var res []sdk.WorkIssueAttachments
+	assert.NoError(json.Unmarshal([]byte(webhook.pipe.Written[0].(*agent.UpdateData).Push["attachments"]), &res))
+	assert.Equal("10001", res[0].RefID)
+	assert.Empty(res[0].AttachmentID)
+	assert.Empty(manager.stored)
+	assert.Empty(jira.downloads)
+
+	webhook = newWebhook("attachment_deleted", "redact")
+	assert.NoError(integration.WebHook(webhook))
+	assert.Len(webhook.pipe.Written, 1)
+
+	// skipped issues aren't exported so they don't get it at all
+	webhook = newWebhook("attachment_created", "skip")
+	assert.NoError(integration.WebHook(webhook))
+	assert.Empty(webhook.pipe.Written)
+	webhook = newWebhook("attachment_deleted", "skip")
+	assert.NoError(integration.WebHook(webhook))
+	assert.Empty(webhook.pipe.Written)
+}
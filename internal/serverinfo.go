@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const (
+	// apiPathV3 is the rest api on cloud, it's the only version which uses ADF for descriptions and comments
+	apiPathV3 = "/rest/api/3"
+	// apiPathV2 is the rest api on server and data center, v3 doesn't exist there
+	apiPathV2 = "/rest/api/2"
+	// serverInfoPath is in v2 so that we can ask before we know which version to use
+	serverInfoPath = "/rest/api/2/serverInfo"
+)
+
+// the deployment types from the server info
+const (
+	deploymentCloud      = "Cloud"
+	deploymentServer     = "Server"
+	deploymentDataCenter = "DataCenter"
+)
+
+type serverInfo struct {
+	BaseURL        string `json:"baseUrl"`
+	Version        string `json:"version"`
+	VersionNumbers []int  `json:"versionNumbers"`
+	DeploymentType string `json:"deploymentType"`
+}
+
+// easyjson:skip
+type apiInfo struct {
+	path       string
+	deployment string
+}
+
+// isCloudURL returns true for the sites which can only be jira cloud, so we don't have to ask
+func isCloudURL(apiURL string) bool {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "api.atlassian.com" || strings.HasSuffix(host, ".atlassian.net") || strings.HasSuffix(host, ".jira.com")
+}
+
+// detectAPI sets the api path and deployment type of the auth config. it's only asked for once for each site since it
+// doesn't change, and the auth config should already have its middleware so the request is rate limited
+func (i *JiraIntegration) detectAPI(logger sdk.Logger, authConfig *authConfig) error {
+	i.lock.Lock()
+	info, ok := i.apis[authConfig.APIURL]
+	i.lock.Unlock()
+	if !ok {
+		if isCloudURL(authConfig.APIURL) {
+			info = apiInfo{path: apiPathV3, deployment: deploymentCloud}
+		} else {
+			client := i.httpmanager.New(sdk.JoinURL(authConfig.APIURL, serverInfoPath), nil)
+			var resp serverInfo
+			if _, err := client.Get(&resp, authConfig.Middleware...); err != nil {
+				return fmt.Errorf("error fetching server info: %w", err)
+			}
+			info = apiInfo{path: apiPathV2, deployment: resp.DeploymentType}
+			if resp.DeploymentType == deploymentCloud {
+				info.path = apiPathV3
+			}
+			sdk.LogInfo(logger, "detected jira deployment", "deployment", resp.DeploymentType, "version", resp.Version, "api", info.path)
+		}
+		i.lock.Lock()
+		if i.apis == nil {
+			i.apis = make(map[string]apiInfo)
+		}
+		i.apis[authConfig.APIURL] = info
+		i.lock.Unlock()
+	}
+	authConfig.APIPath = info.path
+	authConfig.Deployment = info.deployment
+	return nil
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/pinpt/agent/v4/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCloudURL(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(isCloudURL("https://pinpt-hq.atlassian.net"))
+	assert.True(isCloudURL("https://api.atlassian.com/ex/jira/1234"))
+	assert.True(isCloudURL("https://example.jira.com"))
+	assert.False(isCloudURL("https://jira.example.com"))
+	assert.False(isCloudURL("http://127.0.0.1:8080"))
+}
+
+func TestDetectAPI(t *testing.T) {
+	for _, deployment := range testDeployments {
+		t.Run(deployment, func(t *testing.T) {
+			assert := assert.New(t)
+			jira := newFakeJiraDeployment(deployment)
+			integration := newMockIntegration()
+			config := authConfig{APIURL: jira.URL()}
+			assert.NoError(integration.detectAPI(sdk.NewNoOpTestLogger(), &config))
+			assert.Equal(deployment, config.Deployment)
+			assert.Equal(jira.apiPath(), config.APIPath)
+			assert.Equal(deployment == deploymentCloud, config.usesADF())
+			assert.Equal(jira.URL()+jira.apiPath()+"/issue/ABC-1", config.restURL("/issue", "ABC-1"))
+			// it's only asked for once
+			jira.Close()
+			config = authConfig{APIURL: jira.URL()}
+			assert.NoError(integration.detectAPI(sdk.NewNoOpTestLogger(), &config))
+			assert.Equal(deployment, config.Deployment)
+		})
+	}
+}
+
+func TestDetectAPICloudURL(t *testing.T) {
+	assert := assert.New(t)
+	config := authConfig{APIURL: "https://pinpt-hq.atlassian.net"}
+	// doesn't need to ask
+	assert.NoError((&JiraIntegration{}).detectAPI(sdk.NewNoOpTestLogger(), &config))
+	assert.Equal(apiPathV3, config.APIPath)
+	assert.Equal(deploymentCloud, config.Deployment)
+}
@@ -0,0 +1,415 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const (
+	// exportSummariesStateKey is the state key for the summaries of the most recent exports, newest first
+	exportSummariesStateKey = "export_summaries"
+	// maxExportSummaries is how many export summaries we keep
+	maxExportSummaries = 10
+	// maxLatencySamples is how many latencies we keep to work out the percentiles from, so a long export doesn't
+	// keep every one of them
+	maxLatencySamples = 1000
+)
+
+// the phases of an export we time. boards are fetched in the background while the issues are fetched so they overlap
+const (
+	phaseCustomFields = "custom_fields"
+	phaseWorkConfig   = "work_config"
+	phaseProjects     = "projects"
+	phaseBoards       = "boards"
+	phaseIssues       = "issues"
+	phaseReconcile    = "reconcile"
+)
+
+// easyjson:skip
+type endpointTelemetry struct {
+	requests      int
+	errors        int
+	retries       int
+	bytesSent     int64
+	bytesReceived int64
+	latencies     latencySample
+}
+
+// latencySample is a uniform random sample of at most maxLatencySamples latencies, with the max of all of them
+// easyjson:skip
+type latencySample struct {
+	count   int
+	max     time.Duration
+	samples []time.Duration
+}
+
+// add uses reservoir sampling so every latency has the same chance of being in the sample
+func (s *latencySample) add(latency time.Duration, rnd *rand.Rand) {
+	s.count++
+	if latency > s.max {
+		s.max = latency
+	}
+	if len(s.samples) < maxLatencySamples {
+		s.samples = append(s.samples, latency)
+		return
+	}
+	if n := rnd.Intn(s.count); n < maxLatencySamples {
+		s.samples[n] = latency
+	}
+}
+
+func (s *latencySample) summary() latencySummary {
+	summary := summarizeLatencies(s.samples)
+	summary.Max = s.max.Milliseconds()
+	return summary
+}
+
+// exportTelemetry collects what every request made during an export did, for support
+// easyjson:skip
+type exportTelemetry struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	rand        *rand.Rand
+	started     time.Time
+	endpoints   map[string]*endpointTelemetry
+	latencies   latencySample
+	phases      map[string]time.Duration
+	pauses      int
+	pausedAt    time.Time
+	pausedTotal time.Duration
+}
+
+func newExportTelemetry() *exportTelemetry {
+	return &exportTelemetry{
+		now:       time.Now,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		started:   time.Now(),
+		endpoints: make(map[string]*endpointTelemetry),
+		phases:    make(map[string]time.Duration),
+	}
+}
+
+// idSegmentRegexp matches the parts of a path which are ids or issue keys
+var idSegmentRegexp = regexp.MustCompile(`^(\d+|[A-Za-z][A-Za-z0-9_]*-\d+)$`)
+
+// telemetryEndpoint returns the endpoint for a request, with the ids taken out of the path so they are grouped together
+func telemetryEndpoint(method string, path string) string {
+	segments := strings.Split(path, "/")
+	for n, segment := range segments {
+		// leave the version in /rest/api/3 or /rest/api/2 alone
+		if n > 0 && segments[n-1] == "api" {
+			continue
+		}
+		if idSegmentRegexp.MatchString(segment) {
+			segments[n] = "{id}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+// must be called with the lock held
+func (t *exportTelemetry) endpoint(name string) *endpointTelemetry {
+	e := t.endpoints[name]
+	if e == nil {
+		e = &endpointTelemetry{}
+		t.endpoints[name] = e
+	}
+	return e
+}
+
+func (t *exportTelemetry) recordResponse(endpoint string, statusCode int, latency time.Duration, bytesSent int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := t.endpoint(endpoint)
+	e.requests++
+	e.latencies.add(latency, t.rand)
+	t.latencies.add(latency, t.rand)
+	if bytesSent > 0 {
+		e.bytesSent += bytesSent
+	}
+	// a status of zero is a request which didn't get a response
+	if statusCode == 0 || statusCode > 299 {
+		e.errors++
+	}
+	if isStatusRetried(statusCode) {
+		e.retries++
+	}
+}
+
+func (t *exportTelemetry) recordBytesReceived(endpoint string, n int64) {
+	t.mu.Lock()
+	t.endpoint(endpoint).bytesReceived += n
+	t.mu.Unlock()
+}
+
+// isStatusRetried returns true for the status codes the agent's http client retries
+func isStatusRetried(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// phase starts timing a phase of the export, call the func returned when it's done
+func (t *exportTelemetry) phase(name string) func() {
+	started := t.now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			t.phases[name] += t.now().Sub(started)
+			t.mu.Unlock()
+		})
+	}
+}
+
+func (t *exportTelemetry) paused() {
+	t.mu.Lock()
+	t.pauses++
+	t.pausedAt = t.now()
+	t.mu.Unlock()
+}
+
+func (t *exportTelemetry) resumed() {
+	t.mu.Lock()
+	if !t.pausedAt.IsZero() {
+		t.pausedTotal += t.now().Sub(t.pausedAt)
+		t.pausedAt = time.Time{}
+	}
+	t.mu.Unlock()
+}
+
+// middleware returns the http option which records the requests sent with it
+func (t *exportTelemetry) middleware() sdk.WithHTTPOption {
+	return func(opt *sdk.HTTPOptions) error {
+		if opt.Response != nil {
+			return nil
+		}
+		// every attempt gets new options so this only wraps the transport once for each request sent
+		next := opt.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		opt.Transport = &telemetryTransport{telemetry: t, next: next}
+		return nil
+	}
+}
+
+// easyjson:skip
+type telemetryTransport struct {
+	telemetry *exportTelemetry
+	next      http.RoundTripper
+}
+
+func (tt *telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := telemetryEndpoint(req.Method, req.URL.Path)
+	started := tt.telemetry.now()
+	resp, err := tt.next.RoundTrip(req)
+	if err != nil {
+		tt.telemetry.recordResponse(endpoint, 0, tt.telemetry.now().Sub(started), req.ContentLength)
+		return nil, err
+	}
+	tt.telemetry.recordResponse(endpoint, resp.StatusCode, tt.telemetry.now().Sub(started), req.ContentLength)
+	resp.Body = &countingBody{ReadCloser: resp.Body, telemetry: tt.telemetry, endpoint: endpoint}
+	return resp, nil
+}
+
+// countingBody records the size of a response body as it's read
+// easyjson:skip
+type countingBody struct {
+	io.ReadCloser
+	telemetry *exportTelemetry
+	endpoint  string
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.telemetry.recordBytesReceived(b.endpoint, int64(n))
+	}
+	return n, err
+}
+
+// telemetryExport tells the telemetry when the export is paused and resumed for rate limiting
+// easyjson:skip
+type telemetryExport struct {
+	sdk.Export
+	telemetry *exportTelemetry
+}
+
+func (e *telemetryExport) Paused(resetAt time.Time) error {
+	e.telemetry.paused()
+	return e.Export.Paused(resetAt)
+}
+
+func (e *telemetryExport) Resumed() error {
+	e.telemetry.resumed()
+	return e.Export.Resumed()
+}
+
+// easyjson:skip
+type latencySummary struct {
+	P50 int64 `json:"p50_ms"`
+	P90 int64 `json:"p90_ms"`
+	P99 int64 `json:"p99_ms"`
+	Max int64 `json:"max_ms"`
+}
+
+// percentile uses the nearest rank of p in the sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func summarizeLatencies(latencies []time.Duration) latencySummary {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	summary := latencySummary{
+		P50: percentile(sorted, 50).Milliseconds(),
+		P90: percentile(sorted, 90).Milliseconds(),
+		P99: percentile(sorted, 99).Milliseconds(),
+	}
+	if len(sorted) > 0 {
+		summary.Max = sorted[len(sorted)-1].Milliseconds()
+	}
+	return summary
+}
+
+// easyjson:skip
+type endpointSummary struct {
+	Endpoint      string         `json:"endpoint"`
+	Requests      int            `json:"requests"`
+	Errors        int            `json:"errors"`
+	Retries       int            `json:"retries"`
+	BytesSent     int64          `json:"bytes_sent"`
+	BytesReceived int64          `json:"bytes_received"`
+	Latency       latencySummary `json:"latency"`
+}
+
+// exportSummary is what we keep about an export so that support can see how it went
+// easyjson:skip
+type exportSummary struct {
+	JobID           string            `json:"job_id"`
+	Started         time.Time         `json:"started"`
+	DurationMs      int64             `json:"duration_ms"`
+	Historical      bool              `json:"historical"`
+	Error           string            `json:"error,omitempty"`
+	Requests        int               `json:"requests"`
+	Errors          int               `json:"errors"`
+	Retries         int               `json:"retries"`
+	BytesSent       int64             `json:"bytes_sent"`
+	BytesReceived   int64             `json:"bytes_received"`
+	Latency         latencySummary    `json:"latency"`
+	RateLimitPauses int               `json:"rate_limit_pauses"`
+	RateLimitedMs   int64             `json:"rate_limited_ms"`
+	PhasesMs        map[string]int64  `json:"phases_ms"`
+	Counts          map[string]int    `json:"counts"`
+	Endpoints       []endpointSummary `json:"endpoints"`
+}
+
+// summary returns the summary of the export so far, including the entity counts from stats if we got that far
+func (t *exportTelemetry) summary(jobID string, historical bool, stats *stats, exportErr error) exportSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	summary := exportSummary{
+		JobID:           jobID,
+		Started:         t.started.UTC(),
+		DurationMs:      now.Sub(t.started).Milliseconds(),
+		Historical:      historical,
+		RateLimitPauses: t.pauses,
+		RateLimitedMs:   t.pausedTotal.Milliseconds(),
+		PhasesMs:        make(map[string]int64),
+		Counts:          make(map[string]int),
+		Endpoints:       make([]endpointSummary, 0),
+	}
+	if exportErr != nil {
+		summary.Error = exportErr.Error()
+	}
+	if !t.pausedAt.IsZero() {
+		// we stopped while we were still rate limited
+		summary.RateLimitedMs += now.Sub(t.pausedAt).Milliseconds()
+	}
+	for name, d := range t.phases {
+		summary.PhasesMs[name] = d.Milliseconds()
+	}
+	for name, e := range t.endpoints {
+		summary.Endpoints = append(summary.Endpoints, endpointSummary{
+			Endpoint:      name,
+			Requests:      e.requests,
+			Errors:        e.errors,
+			Retries:       e.retries,
+			BytesSent:     e.bytesSent,
+			BytesReceived: e.bytesReceived,
+			Latency:       e.latencies.summary(),
+		})
+		summary.Requests += e.requests
+		summary.Errors += e.errors
+		summary.Retries += e.retries
+		summary.BytesSent += e.bytesSent
+		summary.BytesReceived += e.bytesReceived
+	}
+	summary.Latency = t.latencies.summary()
+	// busiest endpoints first
+	sort.Slice(summary.Endpoints, func(i, j int) bool {
+		a, b := summary.Endpoints[i], summary.Endpoints[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Endpoint < b.Endpoint
+	})
+	if stats != nil {
+		stats.mu.Lock()
+		summary.Counts["issues"] = stats.issueCount
+		summary.Counts["comments"] = stats.commentCount
+		summary.Counts["projects"] = stats.projectCount
+		summary.Counts["priorities"] = stats.priorityCount
+		summary.Counts["types"] = stats.typeCount
+		summary.Counts["sprints"] = stats.sprintCount
+		summary.Counts["users"] = stats.userCount
+		stats.mu.Unlock()
+	}
+	return summary
+}
+
+// loadExportSummaries returns the summaries of the most recent exports, newest first
+func loadExportSummaries(state sdk.State) ([]exportSummary, error) {
+	summaries := make([]exportSummary, 0)
+	if _, err := state.Get(exportSummariesStateKey, &summaries); err != nil {
+		return nil, fmt.Errorf("error getting export summaries from state: %w", err)
+	}
+	return summaries, nil
+}
+
+// saveExportSummary adds the summary to the ones in state, dropping the oldest when there are more than maxExportSummaries
+func saveExportSummary(state sdk.State, summary exportSummary) error {
+	summaries, err := loadExportSummaries(state)
+	if err != nil {
+		return err
+	}
+	summaries = append([]exportSummary{summary}, summaries...)
+	if len(summaries) > maxExportSummaries {
+		summaries = summaries[:maxExportSummaries]
+	}
+	if err := state.Set(exportSummariesStateKey, summaries); err != nil {
+		return fmt.Errorf("error saving export summaries to state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryEndpoint(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("GET /rest/api/3/search", telemetryEndpoint("GET", "/rest/api/3/search"))
+	assert.Equal("GET /rest/api/3/issue/{id}/comment", telemetryEndpoint("GET", "/rest/api/3/issue/ABC-12/comment"))
+	assert.Equal("GET /rest/agile/1.0/board/{id}/sprint", telemetryEndpoint("GET", "/rest/agile/1.0/board/80/sprint"))
+}
+
+func TestSummarizeLatencies(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(latencySummary{}, summarizeLatencies(nil))
+	var latencies []time.Duration
+	for n := 100; n > 0; n-- {
+		latencies = append(latencies, time.Duration(n)*time.Millisecond)
+	}
+	assert.Equal(latencySummary{P50: 50, P90: 90, P99: 99, Max: 100}, summarizeLatencies(latencies))
+}
+
+func TestLatencySampleIsBounded(t *testing.T) {
+	assert := assert.New(t)
+	telemetry := newExportTelemetry()
+	for n := 1; n <= 10*maxLatencySamples; n++ {
+		telemetry.recordResponse("GET /rest/api/3/search", 200, time.Duration(n)*time.Millisecond, 0)
+	}
+	e := telemetry.endpoints["GET /rest/api/3/search"]
+	assert.Len(e.latencies.samples, maxLatencySamples)
+	assert.Len(telemetry.latencies.samples, maxLatencySamples)
+	summary := telemetry.summary("job", false, nil, nil)
+	assert.Equal(10*maxLatencySamples, summary.Requests)
+	assert.Equal(int64(10*maxLatencySamples), summary.Latency.Max)
+	// the sample is random so the median is only roughly the middle
+	assert.InDelta(5*maxLatencySamples, summary.Latency.P50, maxLatencySamples)
+}
+
+func TestExportTelemetrySummary(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	telemetry := newExportTelemetry()
+	telemetry.now = func() time.Time { return now }
+	telemetry.started = now
+	endIssues := telemetry.phase(phaseIssues)
+	telemetry.recordResponse("GET /rest/api/3/search", 200, 100*time.Millisecond, 0)
+	telemetry.recordBytesReceived("GET /rest/api/3/search", 1000)
+	telemetry.recordResponse("GET /rest/api/3/search", 429, 10*time.Millisecond, 0)
+	telemetry.paused()
+	now = now.Add(time.Minute)
+	telemetry.resumed()
+	endIssues()
+	endIssues() // only counted once
+	telemetry.recordResponse("POST /rest/api/3/issue", 201, 200*time.Millisecond, 50)
+	summary := telemetry.summary("job", true, &stats{issueCount: 3}, errors.New("boom"))
+	assert.Equal("job", summary.JobID)
+	assert.Equal("boom", summary.Error)
+	assert.Equal(int64(60000), summary.DurationMs)
+	assert.Equal(3, summary.Requests)
+	assert.Equal(1, summary.Errors)
+	assert.Equal(1, summary.Retries)
+	assert.Equal(int64(50), summary.BytesSent)
+	assert.Equal(int64(1000), summary.BytesReceived)
+	assert.Equal(1, summary.RateLimitPauses)
+	assert.Equal(int64(60000), summary.RateLimitedMs)
+	assert.Equal(map[string]int64{phaseIssues: 60000}, summary.PhasesMs)
+	assert.Equal(3, summary.Counts["issues"])
+	assert.Len(summary.Endpoints, 2)
+	assert.Equal(endpointSummary{
+		Endpoint:      "GET /rest/api/3/search",
+		Requests:      2,
+		Errors:        1,
+		Retries:       1,
+		BytesReceived: 1000,
+		Latency:       latencySummary{P50: 10, P90: 100, P99: 100, Max: 100},
+	}, summary.Endpoints[0])
+	assert.Equal(latencySummary{P50: 100, P90: 200, P99: 200, Max: 200}, summary.Latency)
+}
+
+func TestSaveExportSummaryKeepsTheMostRecent(t *testing.T) {
+	assert := assert.New(t)
+	state := newMockState()
+	for n := 0; n < maxExportSummaries+2; n++ {
+		assert.NoError(saveExportSummary(state, exportSummary{JobID: fmt.Sprint(n)}))
+	}
+	summaries, err := loadExportSummaries(state)
+	assert.NoError(err)
+	assert.Len(summaries, maxExportSummaries)
+	assert.Equal(fmt.Sprint(maxExportSummaries+1), summaries[0].JobID)
+	assert.Equal("2", summaries[maxExportSummaries-1].JobID)
+}
+
+func TestExportSavesTelemetry(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.throttled = 1
+
+	export := newMockExport(jira.URL(), newMockState(), true)
+	integration := newMockIntegration()
+	assert.NoError(integration.Export(export))
+
+	validate := newMockValidate(jira.URL(), map[string]interface{}{"action": ExportTelemetry})
+	validate.state = export.state
+	result, err := integration.Validate(validate)
+	assert.NoError(err)
+	summaries := result["exports"].([]exportSummary)
+	assert.Len(summaries, 1)
+	summary := summaries[0]
+	assert.Equal("job", summary.JobID)
+	assert.True(summary.Historical)
+	assert.Empty(summary.Error)
+	assert.Equal(5, summary.Counts["issues"])
+	assert.Equal(1, summary.Retries)
+	assert.Equal(1, summary.RateLimitPauses)
+	assert.True(summary.BytesReceived > 0)
+	for _, phase := range []string{phaseCustomFields, phaseWorkConfig, phaseProjects, phaseIssues, phaseReconcile} {
+		assert.Contains(summary.PhasesMs, phase)
+	}
+	var found bool
+	for _, e := range summary.Endpoints {
+		if e.Endpoint == "GET /rest/api/3/search" {
+			found = true
+			assert.True(e.Requests > 0)
+		}
+	}
+	assert.True(found)
+}
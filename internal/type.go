@@ -6,11 +6,29 @@ import (
 	"github.com/pinpt/agent/v4/sdk"
 )
 
-func getMappedIssueType(name string, subtask bool) sdk.WorkIssueTypeMappedType {
-	if subtask {
+// the hierarchy levels jira cloud gives issue types. the types in team-managed projects belong to the project and can be
+// renamed, so the level is the only reliable way to tell an epic. server doesn't send it so everything is a standard level
+const (
+	hierarchyLevelSubtask  = -1
+	hierarchyLevelStandard = 0
+	hierarchyLevelEpic     = 1
+)
+
+// parentIsEpic returns true if the parent of an issue of this type is its epic. team-managed projects link issues to
+// their epic with the parent field instead of the Epic Link custom field, and jira cloud now does the same for
+// company-managed projects. only subtasks, and epics under a higher level of the hierarchy, have a real parent
+func parentIsEpic(subtask bool, hierarchyLevel int) bool {
+	return !subtask && hierarchyLevel == hierarchyLevelStandard
+}
+
+func getMappedIssueType(name string, subtask bool, hierarchyLevel int) sdk.WorkIssueTypeMappedType {
+	if subtask || hierarchyLevel == hierarchyLevelSubtask {
 		// any subtask will have this flag set
 		return sdk.WorkIssueTypeMappedTypeSubtask
 	}
+	if hierarchyLevel == hierarchyLevelEpic {
+		return sdk.WorkIssueTypeMappedTypeEpic
+	}
 	// map out of the box jira types that are known
 	switch name {
 	case "Story":
@@ -39,7 +57,7 @@ func (t issueType) ToModel(customerID string, integrationInstanceID string) (*sd
 	issuetype.IntegrationInstanceID = sdk.StringPointer(integrationInstanceID)
 	issuetype.Description = sdk.StringPointer(t.Description)
 	issuetype.IconURL = sdk.StringPointer(t.Icon)
-	issuetype.MappedType = getMappedIssueType(t.Name, t.Subtask)
+	issuetype.MappedType = getMappedIssueType(t.Name, t.Subtask, t.HierarchyLevel)
 	issuetype.ID = sdk.NewWorkIssueTypeID(customerID, refType, t.ID)
 	return issuetype, nil
 }
@@ -50,7 +68,7 @@ type issueTypesResult struct {
 }
 
 func (i *JiraIntegration) fetchIssueTypesForProject(state *state, projectRefID string) ([]sdk.WorkProjectIssueTypes, error) {
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "rest/api/3/project/"+projectRefID+"/statuses")
+	theurl := state.authConfig.restURL("/project", projectRefID, "/statuses")
 	client := i.httpmanager.New(theurl, nil)
 	results := make([]sdk.WorkProjectIssueTypes, 0)
 	resp := make([]issueTypesResult, 0)
@@ -187,6 +187,12 @@ type state struct {
 	client                sdk.GraphQLClient
 	historical            bool
 	integrationInstanceID string
+	issueConcurrency      int
+	issueFilter           string
+	restriction           restrictionPolicy
+	checkpoint            *exportCheckpoint
+	attachments           *attachmentMirror
+	comments              *exportedComments
 }
 
 type jiraErrResp struct {
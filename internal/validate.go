@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -16,6 +17,12 @@ const (
 	ValidateURL = "VALIDATE_URL"
 	// FetchAccounts will fetch accounts
 	FetchAccounts = "FETCH_ACCOUNTS"
+	// ValidateJQL will check that an issue filter is valid jql and return the number of issues it matches
+	ValidateJQL = "VALIDATE_JQL"
+	// EstimateExport will return the number of issues, boards and sprints an export would fetch and how long it would take
+	EstimateExport = "ESTIMATE_EXPORT"
+	// ExportTelemetry will return the summaries of the most recent exports
+	ExportTelemetry = "EXPORT_TELEMETRY"
 )
 
 type projectSearchResult struct {
@@ -56,12 +63,12 @@ func (i *JiraIntegration) Validate(validate sdk.Validate) (map[string]interface{
 		if err != nil {
 			return nil, fmt.Errorf("error creating auth config: %w", err)
 		}
-		projectURL := sdk.JoinURL(authConfig.APIURL, "/rest/api/3/project/search")
+		projectURL := authConfig.restURL("/project/search")
 		client := i.httpmanager.New(projectURL, nil)
 		qs := make(url.Values)
 		qs.Set("maxResults", "1") // NOTE: We just need the total, this would be 0, but 1 is the minimum value.
 		qs.Set("status", "live")
-		qs.Set("typeKey", "software")
+		qs.Set("typeKey", strings.Join(projectTypes(config), ","))
 		var resp projectSearchResult
 		sdk.LogDebug(logger, "fetching project count")
 		r, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(qs))...)
@@ -114,6 +121,62 @@ func (i *JiraIntegration) Validate(validate sdk.Validate) (map[string]interface{
 		return map[string]interface{}{
 			"accounts": acc,
 		}, nil
+	case ValidateJQL:
+		found, jql := config.GetString("jql")
+		if !found {
+			jql = issueFilter(config)
+		}
+		jql = strings.TrimSpace(jql)
+		if jql == "" {
+			return nil, fmt.Errorf("jql validation had no jql")
+		}
+		if err := validateIssueFilter(jql); err != nil {
+			return map[string]interface{}{
+				"valid":  false,
+				"errors": []string{err.Error()},
+			}, nil
+		}
+		authConfig, err := i.createAuthConfig(validate)
+		if err != nil {
+			return nil, fmt.Errorf("error creating auth config: %w", err)
+		}
+		client := i.httpmanager.New(authConfig.restURL("/search"), nil)
+		qs := make(url.Values)
+		qs.Set("jql", jql)
+		qs.Set("fields", "id")
+		qs.Set("maxResults", "0") // we only need the total
+		var resp issueQueryResult
+		if _, err := client.Get(&resp, append(authConfig.Middleware, sdk.WithGetQueryParameters(qs))...); err != nil {
+			if ok, status, body := sdk.IsHTTPError(err); ok && status == http.StatusBadRequest {
+				// jira will tell us what is wrong with the query
+				var errResp jiraErrResp
+				if derr := json.NewDecoder(body).Decode(&errResp); derr == nil && len(errResp.ErrorMessages) > 0 {
+					return map[string]interface{}{
+						"valid":  false,
+						"errors": errResp.ErrorMessages,
+					}, nil
+				}
+			}
+			return nil, fmt.Errorf("error validating jql: %w", err)
+		}
+		return map[string]interface{}{
+			"valid": true,
+			"total": resp.Total,
+		}, nil
+	case EstimateExport:
+		authConfig, err := i.createAuthConfig(validate)
+		if err != nil {
+			return nil, fmt.Errorf("error creating auth config: %w", err)
+		}
+		return i.estimateExport(logger, config, authConfig)
+	case ExportTelemetry:
+		summaries, err := loadExportSummaries(validate.State())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"exports": summaries,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown action %s", action)
 	}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pinpt/agent/v4/sdk"
+)
+
+const projectWatermarksStateKey = "project_last_export_ts"
+
+// projectWatermarks tracks when the issues for each project were last exported so each project can be exported incrementally
+// easyjson:skip
+type projectWatermarks struct {
+	state sdk.State
+	times map[string]time.Time
+	// legacy is the single timestamp we used for every project before we tracked them individually
+	legacy time.Time
+}
+
+func loadProjectWatermarks(state sdk.State) (*projectWatermarks, error) {
+	w := &projectWatermarks{
+		state: state,
+		times: make(map[string]time.Time),
+	}
+	if _, err := state.Get(projectWatermarksStateKey, &w.times); err != nil {
+		return nil, fmt.Errorf("error fetching project watermarks from state: %w", err)
+	}
+	// it's kept until every project has its own watermark, which can take more than one export if one fails
+	var fromTimeStr string
+	if _, err := state.Get(configKeyLastExportTimestamp, &fromTimeStr); err != nil {
+		return nil, fmt.Errorf("error getting last export time from state: %w", err)
+	}
+	if fromTimeStr != "" {
+		w.legacy, _ = time.Parse(time.RFC3339Nano, fromTimeStr)
+	}
+	return w, nil
+}
+
+// get returns when the project was last exported or a zero time if it never has been
+func (w *projectWatermarks) get(projectKey string) time.Time {
+	if ts, ok := w.times[projectKey]; ok {
+		return ts
+	}
+	return w.legacy
+}
+
+// set will record that the projects were exported as of ts
+func (w *projectWatermarks) set(projectKeys []string, ts time.Time) error {
+	for _, key := range projectKeys {
+		w.times[key] = ts
+	}
+	if err := w.state.Set(projectWatermarksStateKey, w.times); err != nil {
+		return fmt.Errorf("error writing project watermarks to state: %w", err)
+	}
+	return nil
+}
+
+// removeLegacy removes the single timestamp once all of the projects have their own watermark, so none of them are
+// exported from the beginning again
+func (w *projectWatermarks) removeLegacy(projectKeys []string) error {
+	if w.legacy.IsZero() {
+		return nil
+	}
+	for _, key := range projectKeys {
+		if _, ok := w.times[key]; !ok {
+			return nil
+		}
+	}
+	if err := w.state.Delete(configKeyLastExportTimestamp); err != nil {
+		return fmt.Errorf("error removing last export date from state: %w", err)
+	}
+	w.legacy = time.Time{}
+	return nil
+}
+
+// easyjson:skip
+type projectExportGroup struct {
+	// fromTime is when to export changes from, zero to export all the history
+	fromTime    time.Time
+	projectKeys []string
+}
+
+// groupProjectsByWatermark groups the projects which can be searched with the same query. new projects are
+// always exported from the beginning so that their history is backfilled
+func groupProjectsByWatermark(watermarks *projectWatermarks, projectKeys []string, newProjectKeys []string) []projectExportGroup {
+	isNew := make(map[string]bool)
+	for _, key := range newProjectKeys {
+		isNew[key] = true
+	}
+	groups := make([]projectExportGroup, 0)
+	index := make(map[string]int)
+	for _, key := range projectKeys {
+		var fromTime time.Time
+		if !isNew[key] {
+			fromTime = watermarks.get(key)
+		}
+		id := fromTime.Format(time.RFC3339Nano)
+		n, ok := index[id]
+		if !ok {
+			n = len(groups)
+			index[id] = n
+			groups = append(groups, projectExportGroup{fromTime: fromTime})
+		}
+		groups[n].projectKeys = append(groups[n].projectKeys, key)
+	}
+	// do the most recent changes first and leave the backfills for last
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].fromTime.After(groups[j].fromTime)
+	})
+	return groups
+}
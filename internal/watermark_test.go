@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupProjectsByWatermark(t *testing.T) {
+	assert := assert.New(t)
+	legacy := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC)
+	watermarks := &projectWatermarks{
+		state:  newMockState(),
+		times:  map[string]time.Time{"1": recent, "2": recent, "3": recent},
+		legacy: legacy,
+	}
+	groups := groupProjectsByWatermark(watermarks, []string{"1", "2", "3", "4", "5"}, []string{"3"})
+	assert.Equal([]projectExportGroup{
+		{fromTime: recent, projectKeys: []string{"1", "2"}},
+		{fromTime: legacy, projectKeys: []string{"4", "5"}},
+		{projectKeys: []string{"3"}},
+	}, groups)
+}
+
+func TestProjectWatermarksMigrateLegacyTimestamp(t *testing.T) {
+	assert := assert.New(t)
+	state := newMockState()
+	legacy := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(state.Set(configKeyLastExportTimestamp, legacy.Format(time.RFC3339Nano)))
+	watermarks, err := loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.True(legacy.Equal(watermarks.get("1")))
+	now := time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(watermarks.set([]string{"1"}, now))
+	// it's still needed by the projects which don't have their own yet
+	assert.NoError(watermarks.removeLegacy([]string{"1", "2"}))
+	assert.True(state.Exists(configKeyLastExportTimestamp))
+	assert.NoError(watermarks.removeLegacy([]string{"1"}))
+	assert.False(state.Exists(configKeyLastExportTimestamp))
+	watermarks, err = loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.True(now.Equal(watermarks.get("1")))
+	assert.True(watermarks.get("2").IsZero())
+}
+
+func TestExportBackfillsNewProjects(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.addBoard(1, 10000, "ABC")
+
+	integration := newMockIntegration()
+	state := newMockState()
+	assert.NoError(integration.Export(newMockExport(jira.URL(), state, true)))
+
+	jira.addProject("10001", "DEF", 5)
+	jira.mu.Lock()
+	jira.jqls = nil
+	jira.mu.Unlock()
+	assert.NoError(integration.Export(newMockExport(jira.URL(), state, false)))
+
+	assert.Len(jira.jqls, 2)
+	// the existing project only fetches what has changed while the new one gets all its history
+	assert.True(strings.HasPrefix(jira.jqls[0], "project in (10000) AND (created >="))
+	assert.Equal("project in (10001) ORDER BY updated DESC", jira.jqls[1])
+
+	watermarks, err := loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.False(watermarks.get("10000").IsZero())
+	assert.False(watermarks.get("10001").IsZero())
+}
+
+func TestExportKeepsLegacyTimestampWhenAGroupFails(t *testing.T) {
+	assert := assert.New(t)
+	jira := newFakeJira()
+	defer jira.Close()
+	jira.addProject("10000", "ABC", 5)
+	jira.addProject("10001", "DEF", 5)
+
+	// the first project has its own watermark and the second still uses the legacy timestamp
+	state := newMockState()
+	legacy := time.Now().Add(-48 * time.Hour).UTC()
+	recent := time.Now().Add(-time.Hour).UTC()
+	assert.NoError(state.Set(configKeyLastExportTimestamp, legacy.Format(time.RFC3339Nano)))
+	assert.NoError(state.Set(projectWatermarksStateKey, map[string]time.Time{"10000": recent}))
+	jira.failSearch = func(jql string, startAt int) bool {
+		return strings.HasPrefix(jql, "project in (10001)")
+	}
+	assert.Error(newMockIntegration().Export(newMockExport(jira.URL(), state, false)))
+
+	watermarks, err := loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.True(watermarks.get("10000").After(recent))
+	assert.True(legacy.Equal(watermarks.get("10001")))
+
+	// once it's exported every project has its own
+	jira.failSearch = nil
+	assert.NoError(newMockIntegration().Export(newMockExport(jira.URL(), state, false)))
+	assert.False(state.Exists(configKeyLastExportTimestamp))
+	watermarks, err = loadProjectWatermarks(state)
+	assert.NoError(err)
+	assert.True(watermarks.get("10001").After(legacy))
+}
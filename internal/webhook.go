@@ -142,6 +142,11 @@ func (i *JiraIntegration) webhookUpdateIssue(logger sdk.Logger, webhook sdk.WebH
 				Project struct {
 					ID string `json:"id"`
 				} `json:"project"`
+				IssueType struct {
+					Subtask        bool `json:"subtask"`
+					HierarchyLevel int  `json:"hierarchyLevel"`
+				} `json:"issuetype"`
+				Security *securityLevel `json:"security"`
 			} `json:"fields"`
 		}
 		Changelog struct {
@@ -156,13 +161,71 @@ func (i *JiraIntegration) webhookUpdateIssue(logger sdk.Logger, webhook sdk.WebH
 	if err != nil {
 		return fmt.Errorf("error creating authconfig: %w", err)
 	}
+	matches, err := i.issueMatchesFilter(logger, webhook, authCfg, issueFilter(webhook.Config()), changelog.Issue.ID)
+	if err != nil {
+		return fmt.Errorf("error checking issue against the issue filter: %w", err)
+	}
+	if !matches {
+		// the change may be what excluded it, so make sure it's removed if we had exported it
+		sdk.LogDebug(logger, "deactivating updated issue excluded by the issue filter", "issue", changelog.Issue.ID)
+		return pipe.Write(sdk.NewWorkIssueDeactivate(customerID, integrationInstanceID, changelog.Issue.ID, refType))
+	}
+	restriction := restrictedContent(webhook.Config())
+	if err := validateRestrictedContent(restriction); err != nil {
+		return err
+	}
+	security := changelog.Issue.Fields.Security
+	if security != nil && restriction == restrictionPolicySkip {
+		sdk.LogDebug(logger, "deactivating updated issue under a security level", "issue", changelog.Issue.ID)
+		return pipe.Write(sdk.NewWorkIssueDeactivate(customerID, integrationInstanceID, changelog.Issue.ID, refType))
+	}
+	for _, change := range changelog.Changelog.Items {
+		if change.Field == "security" {
+			// the security level changes what we export for the whole issue, so export all of it again
+			sdk.LogDebug(logger, "exporting issue again since its security level changed", "issue", changelog.Issue.ID)
+			return i.webhookCreateIssue(logger, webhook, rawdata, pipe)
+		}
+	}
+	customfields, err := i.fetchCachedCustomFields(logger, webhook, webhook.State(), customerID, authCfg)
+	if err != nil {
+		return fmt.Errorf("error fetching custom fields: %w", err)
+	}
+	fieldIDs, err := i.fetchCachedCustomFieldIDs(logger, webhook.State(), customerID, webhook.IntegrationInstanceID(), authCfg, customfields)
+	if err != nil {
+		return err
+	}
+	if err := applyCustomFieldMappings(logger, webhook.Config(), customfields); err != nil {
+		return err
+	}
 	ts := sdk.DateFromEpoch(changelog.Timestamp)
 	val := sdk.WorkIssueUpdate{}
 	var updatedStatus bool
 	for i, change := range changelog.Changelog.Items {
 		var skip bool
-		changeItem := createChangeLog(customerID, changelog.Changelog.ID, changelog.User.RefID(), ts, changelog.Timestamp+int64(i), change)
-		if changeItem != nil {
+		changeItem := createChangeLog(customerID, changelog.Changelog.ID, changelog.User.RefID(), ts, changelog.Timestamp+int64(i), change, customfields, fieldIDs)
+		if changeItem != nil && changeItem.Field == sdk.WorkIssueChangeLogFieldParentID && parentIsEpic(changelog.Issue.Fields.IssueType.Subtask, changelog.Issue.Fields.IssueType.HierarchyLevel) {
+			changeItem.Field = sdk.WorkIssueChangeLogFieldEpicID
+		}
+		if isStoryPointsChange(change, fieldIDs) {
+			// jira only sends number fields as the string, their to is always null
+			if change.ToString == "" {
+				val.Unset.StoryPoints = sdk.BoolPointer(true)
+			} else {
+				storyPoints, err := strconv.ParseFloat(change.ToString, 32)
+				if err != nil {
+					return fmt.Errorf("error parsing story points: %w", err)
+				}
+				sp := float32(storyPoints)
+				val.Set.StoryPoints = &sp
+			}
+		}
+		mapped, isMapped := mappedChangeLogField(customfields, change)
+		if isMapped {
+			if err := setMappedWebhookUpdate(&val, mapped, change); err != nil {
+				return fmt.Errorf("error updating mapped custom field %s: %w", mapped.ID, err)
+			}
+		}
+		if changeItem != nil && !isMapped {
 			switch changeItem.Field {
 			case sdk.WorkIssueChangeLogFieldTitle:
 				val.Set.Title = sdk.StringPointer(change.ToString)
@@ -178,6 +241,12 @@ func (i *JiraIntegration) webhookUpdateIssue(logger sdk.Logger, webhook sdk.WebH
 				} else {
 					val.Set.EpicID = sdk.StringPointer(sdk.NewWorkIssueID(customerID, change.To, refType))
 				}
+			case sdk.WorkIssueChangeLogFieldParentID:
+				if change.To == "" {
+					val.Unset.ParentID = sdk.BoolPointer(true)
+				} else {
+					val.Set.ParentID = sdk.StringPointer(sdk.NewWorkIssueID(customerID, change.To, refType))
+				}
 			case sdk.WorkIssueChangeLogFieldPriority:
 				val.Set.Priority = &sdk.NameID{
 					Name: sdk.StringPointer(change.ToString),
@@ -187,8 +256,16 @@ func (i *JiraIntegration) webhookUpdateIssue(logger sdk.Logger, webhook sdk.WebH
 				assignee := change.To
 				val.Set.AssigneeRefID = &assignee
 			case sdk.WorkIssueChangeLogFieldTags:
-				tags := strings.Split(change.ToString, " ")
-				val.Set.Tags = &tags
+				if hasTagsMapping(customfields) {
+					// setting the labels would remove the tags of the mapped fields
+					pushPullTags(&val, strings.Fields(change.FromString), strings.Fields(change.ToString))
+				} else {
+					tags := strings.Split(change.ToString, " ")
+					if security != nil && restriction == restrictionPolicyExport {
+						tags = append(tags, securityLevelTag(security))
+					}
+					val.Set.Tags = &tags
+				}
 				change.To = change.ToString // to is null, this api is lousy
 			case sdk.WorkIssueChangeLogFieldResolution:
 				val.Set.Resolution = sdk.StringPointer(change.ToString)
@@ -217,11 +294,11 @@ func (i *JiraIntegration) webhookUpdateIssue(logger sdk.Logger, webhook sdk.WebH
 					val.Set.DueDate = &t
 				}
 			}
-		} else {
+		} else if changeItem == nil {
 			skip = true
 		}
 
-		if change.Field == "description" {
+		if change.Field == "description" && !(security != nil && restriction == restrictionPolicyRedact) {
 			// TODO: add description to the datamodel so we can send it in changelog
 			desc := change.ToString
 			if desc != "" {
@@ -318,19 +395,39 @@ func (i *JiraIntegration) webhookCreateIssue(logger sdk.Logger, webhook sdk.WebH
 		return fmt.Errorf("error creating auth config: %w", err)
 	}
 	state := i.newState(logger, pipe, authConfig, webhook.Config(), false, webhook.IntegrationInstanceID())
-	customfields, err := i.fetchCustomFields(logger, state.export, webhook.CustomerID(), state.authConfig)
+	if err := validateRestrictedContent(state.restriction); err != nil {
+		return err
+	}
+	matches, err := i.issueMatchesFilter(logger, webhook, state.authConfig, state.issueFilter, created.Issue.ID)
+	if err != nil {
+		return fmt.Errorf("error checking issue against the issue filter: %w", err)
+	}
+	if !matches {
+		sdk.LogDebug(logger, "skipping new issue excluded by the issue filter", "issue", created.Issue.ID)
+		return nil
+	}
+	customfields, err := i.fetchCachedCustomFields(logger, state.export, webhook.State(), webhook.CustomerID(), state.authConfig)
+	if err != nil {
+		return err
+	}
+	fieldIDs, err := i.fetchCachedCustomFieldIDs(logger, webhook.State(), webhook.CustomerID(), webhook.IntegrationInstanceID(), state.authConfig, customfields)
 	if err != nil {
 		return err
 	}
+	if err := applyCustomFieldMappings(logger, webhook.Config(), customfields); err != nil {
+		return err
+	}
 	sprintMgr := newSprintManager(webhook.CustomerID(), pipe, stats, webhook.IntegrationInstanceID(), state.authConfig.SupportsAgileAPI)
 	userMgr := newUserManager(webhook.CustomerID(), state.authConfig.WebsiteURL, pipe, stats, webhook.IntegrationInstanceID())
-	mgr := newIssueIDManager(logger, i, webhook, pipe, sprintMgr, userMgr, customfields, state.authConfig, stats)
+	mgr := newIssueIDManager(logger, i, webhook, pipe, sprintMgr, userMgr, customfields, state.authConfig, state.issueFilter, state.restriction, stats)
+	mgr.fieldIDs = fieldIDs
+	mgr.attachments = i.newAttachmentMirror(webhook, webhook.State(), state.authConfig, false)
 	issue, comments, err := mgr.fetchIssue(created.Issue.ID, false)
 	if err != nil {
 		return fmt.Errorf("error fetching issue: %w", err)
 	}
 	if issue == nil {
-		sdk.LogDebug(logger, "unable to find issue for webhook", "issue", created.Issue.ID, "customer_id", webhook.CustomerID())
+		sdk.LogDebug(logger, "unable to find issue for webhook or it's restricted", "issue", created.Issue.ID, "customer_id", webhook.CustomerID())
 		return nil
 	}
 	sdk.LogDebug(logger, "sending new issue", "data", issue.Stringify())
@@ -401,15 +498,35 @@ func (i *JiraIntegration) webhookUpsertComment(logger sdk.Logger, webhook sdk.We
 	if err != nil {
 		return fmt.Errorf("error creating authconfig: %w", err)
 	}
+	matches, err := i.issueMatchesFilter(logger, webhook, authcfg, issueFilter(webhook.Config()), created.Issue.ID)
+	if err != nil {
+		return fmt.Errorf("error checking issue against the issue filter: %w", err)
+	}
+	if !matches {
+		sdk.LogDebug(logger, "skipping comment on issue excluded by the issue filter", "comment", created.Comment.ID, "issue", created.Issue.ID)
+		return nil
+	}
+	restriction := restrictedContent(webhook.Config())
+	if err := validateRestrictedContent(restriction); err != nil {
+		return err
+	}
+	var security *securityLevel
+	if restriction != restrictionPolicyExport {
+		// the webhook doesn't have the security level of the issue
+		if security, err = i.fetchIssueSecurityLevel(authcfg, created.Issue.ID); err != nil {
+			return err
+		}
+	}
 	um := newUserManager(customerID, authcfg.WebsiteURL, pipe, nil, integrationInstanceID)
 	// TODO(robin): make a CommentManager interface that we pass in instead
-	comment, err := i.fetchComment(authcfg, um, integrationInstanceID, customerID, created.Issue.ID, created.Issue.Key, created.Comment.ID, created.Issue.Fields.Project.ID)
+	comment, err := i.fetchComment(authcfg, um, integrationInstanceID, customerID, created.Issue.ID, created.Issue.Key, created.Comment.ID, created.Issue.Fields.Project.ID, restriction, security)
 	if err != nil {
 		return fmt.Errorf("error getting comment: %w", err)
 	}
 	if comment == nil {
-		sdk.LogWarn(logger, "unable to find in jira comment from webhook", "comment", created.Comment.ID, "issue", created.Issue.ID)
-		return nil
+		// an update may have restricted it, so make sure it's removed if we had exported it
+		sdk.LogDebug(logger, "deactivating comment from webhook which wasn't found or is restricted", "comment", created.Comment.ID, "issue", created.Issue.ID)
+		return pipe.Write(sdk.NewWorkIssueCommentDeactivate(customerID, integrationInstanceID, created.Comment.ID, refType))
 	}
 	sdk.LogDebug(logger, "sending new comment", "data", sdk.Stringify(comment))
 	return pipe.Write(comment)
@@ -864,6 +981,10 @@ func (i *JiraIntegration) WebHook(webhook sdk.WebHook) error {
 		return i.webhookIssueLinkCreated(logger, customerID, integrationInstanceID, webhook.Bytes(), pipe)
 	case "issuelink_deleted":
 		return i.webhookIssueLinkDeleted(logger, customerID, integrationInstanceID, webhook.Bytes(), pipe)
+	case "attachment_created":
+		return i.webhookUpsertAttachment(logger, webhook)
+	case "attachment_deleted":
+		return i.webhookDeleteAttachment(logger, webhook)
 	default:
 		sdk.LogDebug(webhook.Logger(), "webhook event not handled", "event", event.Event, "payload", string(webhook.Bytes()))
 	}
@@ -30,12 +30,13 @@ type mockWebHook struct {
 	raw    []byte
 	data   map[string]interface{}
 	pipe   *sdktest.MockPipe
+	state  sdk.State
 }
 
 var _ sdk.WebHook = (*mockWebHook)(nil)
 
 func (h *mockWebHook) Config() sdk.Config                    { return h.config }
-func (h *mockWebHook) State() sdk.State                      { return nil }
+func (h *mockWebHook) State() sdk.State                      { return h.state }
 func (h *mockWebHook) RefID() string                         { return "refid" }
 func (h *mockWebHook) Pipe() sdk.Pipe                        { return h.pipe }
 func (h *mockWebHook) Data() (map[string]interface{}, error) { return h.data, nil }
@@ -60,10 +61,27 @@ func makeMockAuth(url string) []byte {
 	}`, url))
 }
 
+// webhookCustomFields are the custom fields of the instance the webhook test data is from
+var webhookCustomFields = map[string]customField{
+	"customfield_10014": {ID: "customfield_10014", Name: "Epic Link", Type: "any", Custom: schemaCustomEpicLink},
+	"customfield_10016": {ID: "customfield_10016", Name: "Story point estimate", Type: fieldTypeNumber, Custom: schemaCustomStoryPointEstimate},
+	"customfield_10107": {ID: "customfield_10107", Name: "Sprint", Type: fieldTypeArray, Items: "json", Custom: schemaCustomSprint},
+	"customfield_10200": {ID: "customfield_10200", Name: "Team", Type: fieldTypeOption},
+	"customfield_10201": {ID: "customfield_10201", Name: "Target start", Type: fieldTypeDate},
+}
+
 func newMockWebHook(fn string) *mockWebHook {
 	pipe := &sdktest.MockPipe{}
 	config := sdk.Config{}
-	if err := config.Parse(makeMockAuth("http://foo.bar/rest")); err != nil {
+	if err := config.Parse(makeMockAuth("https://pinpt-hq.atlassian.net")); err != nil {
+		panic(err)
+	}
+	// the custom fields are cached so the webhooks don't need to fetch them
+	state := newMockState()
+	if err := cacheCustomFields(state, webhookCustomFields); err != nil {
+		panic(err)
+	}
+	if err := cacheCustomFieldIDs(state, findCustomFieldIDs(webhookCustomFields)); err != nil {
 		panic(err)
 	}
 	buf := loadFile(fn)
@@ -72,6 +90,7 @@ func newMockWebHook(fn string) *mockWebHook {
 		raw:    buf,
 		data:   make(map[string]interface{}),
 		pipe:   pipe,
+		state:  state,
 	}
 }
 
@@ -773,3 +792,63 @@ func TestWebhookLinkedIssueUnhanled(t *testing.T) {
 	assert.NoError(webhookHandleIssueLink(logger, "1234", "1", []byte(unhandledLink), pipe, false))
 	assert.Len(pipe.Written, 0)
 }
+
+func TestWebhookJiraIssueUpdatedTeamManagedParent(t *testing.T) {
+	assert := assert.New(t)
+	i := JiraIntegration{}
+	logger := sdk.NewNoOpTestLogger()
+	webhook := newMockWebHook("testdata/jira:issue_updated.parent.json")
+	assert.NoError(i.webhookUpdateIssue(logger, webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.EqualValues(quoteString(sdk.NewWorkIssueID("1234", "10520", refType)), update.Set["epic_id"])
+	assert.Empty(update.Set["parent_id"])
+	assert.EqualValues("5", update.Set["story_points"])
+	var res []sdk.WorkIssueChangeLog
+	json.Unmarshal([]byte(update.Push["change_log"]), &res)
+	assert.Len(res, 1)
+	assert.EqualValues(sdk.WorkIssueChangeLogFieldEpicID, res[0].Field)
+}
+
+func TestWebhookAttachmentCreated(t *testing.T) {
+	assert := assert.New(t)
+	webhook := newMockWebHook("testdata/attachment_created.json")
+	i := JiraIntegration{}
+	assert.NoError(i.WebHook(webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.EqualValues(sdk.NewWorkIssueID("1234", "20192", refType), update.ID)
+	assert.Empty(update.Pull)
+	var res []sdk.WorkIssueAttachments
+	assert.NoError(json.Unmarshal([]byte(update.Push["attachments"]), &res))
+	assert.Len(res, 1)
+	assert.EqualValues("10042", res[0].RefID)
+	assert.EqualValues("screenshot.png", res[0].Name)
+	assert.EqualValues("image/png", res[0].MimeType)
+	assert.EqualValues(48213, res[0].Size)
+	assert.EqualValues("5dd2a6df8a4e4d0ef1ce7b22", res[0].UserRefID)
+	assert.EqualValues("https://pinpt-hq.atlassian.net/secure/attachment/10042/screenshot.png", res[0].URL)
+	assert.EqualValues(1603468815478, res[0].CreatedDate.Epoch)
+}
+
+func TestWebhookAttachmentDeleted(t *testing.T) {
+	assert := assert.New(t)
+	webhook := newMockWebHook("testdata/attachment_deleted.json")
+	i := JiraIntegration{}
+	assert.NoError(i.WebHook(webhook))
+	assert.Len(webhook.pipe.Written, 1)
+	update := webhook.pipe.Written[0].(*agent.UpdateData)
+	assert.EqualValues(sdk.NewWorkIssueID("1234", "20192", refType), update.ID)
+	assert.Empty(update.Push)
+	// it's pulled by the ref id since that's all the webhook has that matches what was pushed
+	assert.JSONEq(`[{"ref_id":"10042"}]`, update.Pull["attachments"])
+}
+
+func TestWebhookAttachmentWithoutIssue(t *testing.T) {
+	assert := assert.New(t)
+	webhook := newMockWebHook("testdata/attachment_created.json")
+	webhook.raw = []byte(`{"webhookEvent":"attachment_created","attachment":{"id":"10042","filename":"a.txt","created":"2020-10-23T09:00:15.478-0700"}}`)
+	i := JiraIntegration{}
+	assert.NoError(i.WebHook(webhook))
+	assert.Empty(webhook.pipe.Written)
+}
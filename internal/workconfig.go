@@ -25,12 +25,11 @@ func (i *JiraIntegration) processWorkConfig(logger sdk.Logger, config sdk.Config
 	}
 	// FIXME(robin): why does a new state need to be declared here instead of useing the one passed in?
 	state := i.newState(logger, pipe, authConfig, config, historical, integrationInstanceID)
-	theurl := sdk.JoinURL(state.authConfig.APIURL, "/rest/api/3/status")
+	theurl := state.authConfig.restURL("/status")
 	client := i.httpmanager.New(theurl, nil)
 	resp := make([]status, 0)
 	ts := time.Now()
-	r, err := client.Get(&resp, state.authConfig.Middleware...)
-	if err := i.checkForRateLimit(logger, state.export, customerID, err, r.Headers); err != nil {
+	if _, err := client.Get(&resp, state.authConfig.Middleware...); err != nil {
 		return err
 	}
 	var wc sdk.WorkConfig